@@ -0,0 +1,45 @@
+package sfdc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// APIVersion is the Salesforce API version returned by the APIs, like 52.0.  It
+// is marshaled as a number with at least one decimal place, and can be unmarshaled
+// from a number or a string.
+type APIVersion float64
+
+// String returns the version with at least one decimal place.
+func (v APIVersion) String() string {
+	version := strconv.FormatFloat(float64(v), 'f', -1, 64)
+	if strings.Contains(version, ".") == false {
+		version += ".0"
+	}
+	return version
+}
+
+// MarshalJSON will marshal the version as a number with at least one decimal place.
+func (v APIVersion) MarshalJSON() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalJSON will unmarshal the version from a number or a string.
+func (v *APIVersion) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	var quoted string
+	if err := json.Unmarshal(data, &quoted); err == nil {
+		text = quoted
+	}
+	version, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return fmt.Errorf("sfdc: %s is not an API version", string(data))
+	}
+	*v = APIVersion(version)
+	return nil
+}
@@ -0,0 +1,76 @@
+package sfdc
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAPIVersion_JSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		want     APIVersion
+		wantJSON string
+		wantErr  bool
+	}{
+		{
+			name:     "decimal",
+			json:     `{"apiVersion":52.0}`,
+			want:     52,
+			wantJSON: `{"apiVersion":52.0}`,
+			wantErr:  false,
+		},
+		{
+			name:     "integer",
+			json:     `{"apiVersion":44}`,
+			want:     44,
+			wantJSON: `{"apiVersion":44.0}`,
+			wantErr:  false,
+		},
+		{
+			name:     "string",
+			json:     `{"apiVersion":"48.0"}`,
+			want:     48,
+			wantJSON: `{"apiVersion":48.0}`,
+			wantErr:  false,
+		},
+		{
+			name:     "fraction",
+			json:     `{"apiVersion":52.1}`,
+			want:     52.1,
+			wantJSON: `{"apiVersion":52.1}`,
+			wantErr:  false,
+		},
+		{
+			name:    "invalid",
+			json:    `{"apiVersion":"v52"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value struct {
+				APIVersion APIVersion `json:"apiVersion"`
+			}
+			err := json.Unmarshal([]byte(tt.json), &value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("APIVersion.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if value.APIVersion != tt.want {
+				t.Errorf("APIVersion.UnmarshalJSON() = %v, want %v", value.APIVersion, tt.want)
+			}
+			got, err := json.Marshal(value)
+			if err != nil {
+				t.Errorf("APIVersion.MarshalJSON() error = %v", err)
+				return
+			}
+			if string(got) != tt.wantJSON {
+				t.Errorf("APIVersion.MarshalJSON() = %s, want %s", got, tt.wantJSON)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package bulk
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/enrique-esquivel/go-sfdc/session"
 	"github.com/pkg/errors"
 )
@@ -8,8 +11,16 @@ import (
 const bulk2Endpoint = "/jobs/ingest"
 
 // Resource is the structure that can be used to create bulk 2.0 jobs.
+//
+// Guard refuses to create a second job with the same ClientRequestID in the
+// options.  This field is optional.
+//
+// MaxPages is the most pages of jobs that PurgeJobs follows.  If it is zero,
+// sfdc.DefaultMaxPages is used.
 type Resource struct {
-	session session.ServiceFormatter
+	session  session.ServiceFormatter
+	Guard    JobGuard
+	MaxPages int
 }
 
 // NewResource creates a new bulk 2.0 REST resource.  If the session is nil
@@ -31,17 +42,49 @@ func NewResource(session session.ServiceFormatter) (*Resource, error) {
 
 // CreateJob will create a new bulk 2.0 job from the options that where passed.
 // The Job that is returned can be used to upload object data to the Salesforce org.
+// If the resource has a guard and the options have a ClientRequestID that the
+// guard has seen, ErrDuplicateJob is returned and the job is not created.
 func (r *Resource) CreateJob(options Options) (*Job, error) {
+	key := options.ClientRequestID
+	if r.Guard != nil && key != "" {
+		if r.Guard.Claim(key) == false {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateJob, key)
+		}
+	}
+
 	job := &Job{
 		session: r.session,
 	}
 	if err := job.create(options); err != nil {
+		if r.Guard != nil && key != "" {
+			r.Guard.Release(key)
+		}
 		return nil, err
 	}
 
 	return job, nil
 }
 
+// Load will create a new bulk 2.0 job, upload the job data and close the job, so
+// the job is ready to be polled for its completion.  If the job data could not be
+// uploaded or the job could not be closed, the job is returned with the error so
+// that it can be aborted or deleted.
+func (r *Resource) Load(options Options, data io.Reader) (*Job, error) {
+	job, err := r.CreateJob(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := job.Upload(data); err != nil {
+		return job, err
+	}
+	if _, err := job.Close(); err != nil {
+		return job, err
+	}
+
+	return job, nil
+}
+
 // GetJob will retrieve an existing bulk 2.0 job using the provided ID.
 func (r *Resource) GetJob(id string) (*Job, error) {
 	job := &Job{
@@ -56,6 +99,28 @@ func (r *Resource) GetJob(id string) (*Job, error) {
 	return job, nil
 }
 
+// SuccessfulResults will retrieve the successful records of an existing bulk 2.0
+// job using the provided ID.  The job information is retrieved for the column
+// delimiter of the results.
+func (r *Resource) SuccessfulResults(jobID string) ([]SuccessfulRecord, error) {
+	job, err := r.GetJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	return job.SuccessfulRecords()
+}
+
+// FailedResults will retrieve the failed records of an existing bulk 2.0 job
+// using the provided ID.  The job information is retrieved for the column
+// delimiter of the results.
+func (r *Resource) FailedResults(jobID string) ([]FailedRecord, error) {
+	job, err := r.GetJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	return job.FailedRecords()
+}
+
 // AllJobs will retrieve all of the bulk 2.0 jobs.
 func (r *Resource) AllJobs(parameters Parameters) (*Jobs, error) {
 	jobs, err := newJobs(r.session, parameters)
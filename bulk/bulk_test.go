@@ -311,3 +311,148 @@ func TestResource_AllJobs(t *testing.T) {
 		})
 	}
 }
+
+func TestResource_Load(t *testing.T) {
+	client, _ := mockRunJobsClient(t, "")
+	uploadErr := mockHTTPClient(func(req *http.Request) *http.Response {
+		if req.Method == http.MethodPut {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       ioutil.NopCloser(strings.NewReader(`[{"message":"bad data","errorCode":"INVALIDJOBSTATE"}]`)),
+				Header:     make(http.Header),
+			}
+		}
+		return client.Transport.(roundTripFunc)(req)
+	})
+	tests := []struct {
+		name    string
+		client  *http.Client
+		options Options
+		wantJob bool
+		want    State
+		wantErr bool
+	}{
+		{
+			name:   "loaded",
+			client: client,
+			options: Options{
+				Object:    "Account",
+				Operation: Insert,
+			},
+			wantJob: true,
+			want:    UpdateComplete,
+			wantErr: false,
+		},
+		{
+			name:   "upload error",
+			client: uploadErr,
+			options: Options{
+				Object:    "Account",
+				Operation: Insert,
+			},
+			wantJob: true,
+			want:    Open,
+			wantErr: true,
+		},
+		{
+			name:   "create error",
+			client: client,
+			options: Options{
+				Operation: Insert,
+			},
+			wantJob: false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resource{
+				session: &mockSessionFormatter{
+					url:    "https://test.salesforce.com",
+					client: tt.client,
+				},
+			}
+			got, err := r.Load(tt.options, strings.NewReader("Name\nsome name\n"))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.Load() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got != nil) != tt.wantJob {
+				t.Errorf("Resource.Load() = %v, want job %v", got, tt.wantJob)
+				return
+			}
+			if got != nil && got.WriteResponse.State != tt.want {
+				t.Errorf("Resource.Load() state = %v, want %v", got.WriteResponse.State, tt.want)
+			}
+		})
+	}
+}
+
+func TestResource_SuccessfulResults(t *testing.T) {
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		response := func(status int, body string) *http.Response {
+			return &http.Response{
+				StatusCode: status,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Header:     make(http.Header),
+			}
+		}
+		switch req.URL.String() {
+		case "https://test.salesforce.com/jobs/ingest/1234":
+			return response(http.StatusOK, `{"id":"1234","state":"JobComplete","columnDelimiter":"PIPE"}`)
+		case "https://test.salesforce.com/jobs/ingest/1234/successfulResults/":
+			return response(http.StatusOK, "sf__Created|sf__Id|Name\ntrue|2345|John\n")
+		default:
+			return response(http.StatusNotFound, `[{"message":"not found","errorCode":"NOT_FOUND"}]`)
+		}
+	})
+	tests := []struct {
+		name    string
+		jobID   string
+		want    []SuccessfulRecord
+		wantErr bool
+	}{
+		{
+			name:  "passing",
+			jobID: "1234",
+			want: []SuccessfulRecord{
+				{
+					Created: true,
+					JobRecord: JobRecord{
+						ID: "2345",
+						UnprocessedRecord: UnprocessedRecord{
+							Fields: map[string]string{
+								"Name": "John",
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "no job",
+			jobID:   "9876",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resource{
+				session: &mockSessionFormatter{
+					url:    "https://test.salesforce.com",
+					client: client,
+				},
+			}
+			got, err := r.SuccessfulResults(tt.jobID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.SuccessfulResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resource.SuccessfulResults() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package bulk
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/enrique-esquivel/go-sfdc/sobject"
+)
+
+const idField = "Id"
+
+// RequiredColumns returns the recommended CSV column order for the job options using
+// the object's describe.  Delete operations only require the record ID.  Update operations
+// start with the record ID and upsert operations start with the external ID field, followed
+// by the fields that the operation is able to write, in describe order.
+func (o Options) RequiredColumns(describe *sobject.DescribeValue) ([]string, error) {
+	if describe == nil {
+		return nil, errors.New("bulk options: describe is required")
+	}
+	if strings.EqualFold(describe.Name, o.Object) == false {
+		return nil, fmt.Errorf("bulk options: describe %s does not match object %s", describe.Name, o.Object)
+	}
+
+	switch o.Operation {
+	case Delete, HardDelete:
+		return []string{idField}, nil
+	case Insert:
+		return writableColumns(describe, nil, func(f sobject.Field) bool {
+			return f.Createable
+		}), nil
+	case Update:
+		return writableColumns(describe, []string{idField}, func(f sobject.Field) bool {
+			return f.Updateable
+		}), nil
+	case Upsert:
+		if o.ExternalIDFieldName == "" {
+			return nil, errors.New("bulk options: external id field name is required for upsert operation")
+		}
+		external, has := describeField(describe, o.ExternalIDFieldName)
+		if has == false {
+			return nil, fmt.Errorf("bulk options: %s is not a field of %s", o.ExternalIDFieldName, describe.Name)
+		}
+		if external.Name != idField && external.ExternalID == false {
+			return nil, fmt.Errorf("bulk options: %s is not an external id field", external.Name)
+		}
+		return writableColumns(describe, []string{external.Name}, func(f sobject.Field) bool {
+			return f.Createable || f.Updateable
+		}), nil
+	default:
+		return nil, fmt.Errorf("bulk options: operation %s is not supported", o.Operation)
+	}
+}
+
+func describeField(describe *sobject.DescribeValue, name string) (sobject.Field, bool) {
+	for _, field := range describe.Fields {
+		if strings.EqualFold(field.Name, name) {
+			return field, true
+		}
+	}
+	return sobject.Field{}, false
+}
+
+func writableColumns(describe *sobject.DescribeValue, leading []string, writable func(sobject.Field) bool) []string {
+	columns := make([]string, 0, len(leading)+len(describe.Fields))
+	columns = append(columns, leading...)
+	for _, field := range describe.Fields {
+		if contains(leading, field.Name) || writable(field) == false {
+			continue
+		}
+		columns = append(columns, field.Name)
+	}
+	return columns
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package bulk
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/enrique-esquivel/go-sfdc/sobject"
+)
+
+func TestOptions_RequiredColumns(t *testing.T) {
+	describe := &sobject.DescribeValue{
+		Name: "Account",
+		Fields: []sobject.Field{
+			{
+				Name: "Id",
+			},
+			{
+				Name:       "Name",
+				Createable: true,
+				Updateable: true,
+			},
+			{
+				Name:       "CreatedDate",
+				Createable: false,
+				Updateable: false,
+			},
+			{
+				Name:       "External__c",
+				Createable: true,
+				Updateable: true,
+				ExternalID: true,
+			},
+			{
+				Name:       "Site",
+				Createable: true,
+			},
+		},
+	}
+	type args struct {
+		describe *sobject.DescribeValue
+	}
+	tests := []struct {
+		name    string
+		options Options
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "insert",
+			options: Options{
+				Object:    "Account",
+				Operation: Insert,
+			},
+			args: args{
+				describe: describe,
+			},
+			want:    []string{"Name", "External__c", "Site"},
+			wantErr: false,
+		},
+		{
+			name: "update",
+			options: Options{
+				Object:    "Account",
+				Operation: Update,
+			},
+			args: args{
+				describe: describe,
+			},
+			want:    []string{"Id", "Name", "External__c"},
+			wantErr: false,
+		},
+		{
+			name: "upsert",
+			options: Options{
+				Object:              "Account",
+				Operation:           Upsert,
+				ExternalIDFieldName: "External__c",
+			},
+			args: args{
+				describe: describe,
+			},
+			want:    []string{"External__c", "Name", "Site"},
+			wantErr: false,
+		},
+		{
+			name: "delete",
+			options: Options{
+				Object:    "Account",
+				Operation: HardDelete,
+			},
+			args: args{
+				describe: describe,
+			},
+			want:    []string{"Id"},
+			wantErr: false,
+		},
+		{
+			name: "upsert not external id",
+			options: Options{
+				Object:              "Account",
+				Operation:           Upsert,
+				ExternalIDFieldName: "Name",
+			},
+			args: args{
+				describe: describe,
+			},
+			wantErr: true,
+		},
+		{
+			name: "object mismatch",
+			options: Options{
+				Object:    "Contact",
+				Operation: Insert,
+			},
+			args: args{
+				describe: describe,
+			},
+			wantErr: true,
+		},
+		{
+			name: "no describe",
+			options: Options{
+				Object:    "Account",
+				Operation: Insert,
+			},
+			args:    args{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.options.RequiredColumns(tt.args.describe)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Options.RequiredColumns() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Options.RequiredColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
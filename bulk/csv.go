@@ -0,0 +1,135 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// NewCSVWriter returns a CSV writer configured with the column delimiter and
+// line ending of the job options, to build job data for uploading.
+func NewCSVWriter(w io.Writer, options Options) *csv.Writer {
+	writer := csv.NewWriter(w)
+	writer.Comma = options.ColumnDelimiter.Rune()
+	writer.UseCRLF = options.LineEnding == CarriageReturnLinefeed
+	return writer
+}
+
+// NewCSVReader returns a CSV reader configured with the column delimiter of
+// the job options, to read job data or results.
+func NewCSVReader(r io.Reader, options Options) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.Comma = options.ColumnDelimiter.Rune()
+	return reader
+}
+
+// ValidateCSV checks that the job data can be parsed with the column delimiter of
+// the job options before it is uploaded.  The fields with the delimiter, quotes or
+// line breaks must be quoted, and every row must have the same number of columns
+// as the header, otherwise Salesforce can load the fields into the wrong columns.
+// The job data built with NewCSVWriter, Marshal or the Formatter is quoted properly.
+func ValidateCSV(r io.Reader, options Options) error {
+	if r == nil {
+		return errors.New("bulk csv: reader can not be nil")
+	}
+
+	reader := NewCSVReader(r, options)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return errors.New("bulk csv: the job data has no header")
+	}
+	if err != nil {
+		return fmt.Errorf("bulk csv: %w", err)
+	}
+	for idx, column := range header {
+		if column == "" {
+			return fmt.Errorf("bulk csv: column %d of the header is empty", idx+1)
+		}
+	}
+
+	for {
+		_, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("bulk csv: %w", err)
+		}
+	}
+}
+
+// ValidateUpload validates the job data with ValidateCSV and returns a reader
+// of the same data to upload, since the validation reads the data.  A reader
+// that can seek is validated and then seeked back to where it started, other
+// readers are buffered in memory.
+func ValidateUpload(r io.Reader, options Options) (io.Reader, error) {
+	if r == nil {
+		return nil, errors.New("bulk csv: reader can not be nil")
+	}
+
+	if seeker, ok := r.(io.ReadSeeker); ok {
+		offset, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, fmt.Errorf("bulk csv: %w", err)
+		}
+		if err := ValidateCSV(seeker, options); err != nil {
+			return nil, err
+		}
+		if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("bulk csv: %w", err)
+		}
+		return seeker, nil
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("bulk csv: %w", err)
+	}
+	if err := ValidateCSV(bytes.NewReader(data), options); err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+// DetectLineEnding reads the first line of the job data and returns its line
+// ending, CarriageReturnLinefeed or Linefeed, so the job options can match the
+// data.  Salesforce can load the carriage returns into the last column when CRLF
+// data is uploaded with the LF line ending.  Line breaks in quoted fields are
+// skipped.  If the data has one line, the line ending is Linefeed.
+//
+// The reader is read up to the end of the first line, so the data should be read
+// again from the start, like with a new bytes.Reader, to be uploaded.
+func DetectLineEnding(r io.Reader) (LineEnding, error) {
+	if r == nil {
+		return "", errors.New("bulk csv: reader can not be nil")
+	}
+
+	reader := bufio.NewReader(r)
+	quoted := false
+	previous := byte(0)
+	for {
+		c, err := reader.ReadByte()
+		if err == io.EOF {
+			return Linefeed, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("bulk csv: %w", err)
+		}
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == '\n' && quoted == false:
+			if previous == '\r' {
+				return CarriageReturnLinefeed, nil
+			}
+			return Linefeed, nil
+		}
+		previous = c
+	}
+}
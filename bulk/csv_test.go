@@ -0,0 +1,224 @@
+package bulk
+
+import (
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewCSVWriter(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		records [][]string
+		want    string
+	}{
+		{
+			name:    "defaults",
+			options: Options{},
+			records: [][]string{{"Name", "Site"}, {"Golang", "Go, Site"}},
+			want:    "Name,Site\nGolang,\"Go, Site\"\n",
+		},
+		{
+			name: "tab and crlf",
+			options: Options{
+				ColumnDelimiter: Tab,
+				LineEnding:      CarriageReturnLinefeed,
+			},
+			records: [][]string{{"Name", "Site"}, {"Golang", "Go, Site"}},
+			want:    "Name\tSite\r\nGolang\tGo, Site\r\n",
+		},
+		{
+			name: "pipe",
+			options: Options{
+				ColumnDelimiter: Pipe,
+			},
+			records: [][]string{{"Name", "Site"}, {"Golang", "Go|Site"}},
+			want:    "Name|Site\nGolang|\"Go|Site\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := &strings.Builder{}
+			writer := NewCSVWriter(sb, tt.options)
+			if err := writer.WriteAll(tt.records); err != nil {
+				t.Errorf("NewCSVWriter() error = %v", err)
+				return
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("NewCSVWriter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCSVReader(t *testing.T) {
+	reader := NewCSVReader(strings.NewReader("Name^Site\nGolang^Go Site\n"), Options{
+		ColumnDelimiter: Caret,
+	})
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Errorf("NewCSVReader() error = %v", err)
+		return
+	}
+	want := [][]string{{"Name", "Site"}, {"Golang", "Go Site"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewCSVReader() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		options Options
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			data:    "Name,Site\n\"Smith, Jr\",\"Line\nBreak\"\n\"Say \"\"Hi\"\"\",\n",
+			wantErr: false,
+		},
+		{
+			name: "pipe",
+			data: "Name|Site\nSmith, Jr|Site\n",
+			options: Options{
+				ColumnDelimiter: Pipe,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "unquoted delimiter",
+			data:    "Name,Site\nSmith, Jr,Site\n",
+			wantErr: true,
+		},
+		{
+			name:    "unquoted newline",
+			data:    "Name,Site\nLine\nBreak,Site\n",
+			wantErr: true,
+		},
+		{
+			name:    "bare quote",
+			data:    "Name,Site\nSay \"Hi\",Site\n",
+			wantErr: true,
+		},
+		{
+			name:    "empty header column",
+			data:    "Name,\nGolang,Site\n",
+			wantErr: true,
+		},
+		{
+			name:    "no header",
+			data:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCSV(strings.NewReader(tt.data), tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCSV() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDetectLineEnding(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    LineEnding
+		wantErr bool
+	}{
+		{
+			name:    "linefeed",
+			data:    "Name,Site\nGolang,Site\n",
+			want:    Linefeed,
+			wantErr: false,
+		},
+		{
+			name:    "carriage return linefeed",
+			data:    "Name,Site\r\nGolang,Site\r\n",
+			want:    CarriageReturnLinefeed,
+			wantErr: false,
+		},
+		{
+			name:    "quoted line break",
+			data:    "\"Name\nFirst\",Site\r\nGolang,Site\r\n",
+			want:    CarriageReturnLinefeed,
+			wantErr: false,
+		},
+		{
+			name:    "one line",
+			data:    "Name,Site",
+			want:    Linefeed,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectLineEnding(strings.NewReader(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DetectLineEnding() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("DetectLineEnding() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateUpload(t *testing.T) {
+	tests := []struct {
+		name    string
+		reader  io.Reader
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "reader",
+			reader:  ioutil.NopCloser(strings.NewReader("Name,Site\nGolang,Site\n")),
+			want:    "Name,Site\nGolang,Site\n",
+			wantErr: false,
+		},
+		{
+			name: "seeker",
+			reader: func() io.Reader {
+				reader := strings.NewReader("skip\nName,Site\nGolang,Site\n")
+				reader.Seek(5, io.SeekStart)
+				return reader
+			}(),
+			want:    "Name,Site\nGolang,Site\n",
+			wantErr: false,
+		},
+		{
+			name:    "ragged rows",
+			reader:  ioutil.NopCloser(strings.NewReader("Name,Site\nGolang\n")),
+			wantErr: true,
+		},
+		{
+			name:    "ragged rows seeker",
+			reader:  strings.NewReader("Name,Site\nGolang,Site,Extra\n"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateUpload(tt.reader, Options{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUpload() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			data, _ := ioutil.ReadAll(got)
+			if string(data) != tt.want {
+				t.Errorf("ValidateUpload() = %q, want %q", string(data), tt.want)
+			}
+		})
+	}
+}
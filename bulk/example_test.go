@@ -0,0 +1,58 @@
+package bulk_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/enrique-esquivel/go-sfdc/bulk"
+	"github.com/enrique-esquivel/go-sfdc/session/sessiontest"
+)
+
+// This example runs a bulk job against a fake Salesforce, which is how the
+// code that uses bulk jobs can be tested.
+func Example_fakeServer() {
+	fake := sessiontest.NewSession(newFakeBulkServer())
+	defer fake.Close()
+
+	resource, err := bulk.NewResource(fake)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	job, err := resource.CreateJob(bulk.Options{
+		Object:    "Account",
+		Operation: bulk.Insert,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := job.Upload(strings.NewReader("Name\nAcme\n\n")); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if _, err := job.Close(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	info, err := job.WaitForComplete(context.Background(), 10*time.Millisecond)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	records, err := job.SuccessfulRecords()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(info.State, info.NumberRecordsProcessed)
+	for _, record := range records {
+		fmt.Println(record.ID, record.Created, record.Fields["Name"])
+	}
+	// Output:
+	// JobComplete 1
+	// 001R00000000001 true Acme
+}
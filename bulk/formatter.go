@@ -7,7 +7,13 @@ import (
 	"strings"
 )
 
-// Record is the interface to the fields of the bulk uploader record.
+// FieldNull is the job data value that sets a field to null.  A field that is
+// not in a record, or whose value is nil, is empty in the job data, which leaves
+// the field unchanged on update.  Setting a record field to FieldNull blanks it.
+const FieldNull = "#N/A"
+
+// Record is the interface to the fields of the bulk uploader record.  If
+// InsertNull is true, the fields that are not in the record are FieldNull.
 type Record interface {
 	Fields() map[string]interface{}
 	InsertNull() bool
@@ -63,7 +69,7 @@ func (f *Formatter) Add(records ...Record) error {
 		insertNull := record.InsertNull()
 		for idx, field := range f.fields {
 			if insertNull {
-				values[idx] = "#N/A"
+				values[idx] = FieldNull
 			} else {
 				values[idx] = ""
 			}
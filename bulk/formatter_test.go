@@ -157,6 +157,41 @@ func TestFormatter_Add(t *testing.T) {
 			want:    "Name|Site\nname 1|good site\nname 2|great site\n",
 			wantErr: false,
 		},
+		{
+			name: "Null Fields",
+			fields: fields{
+				job: &Job{
+					WriteResponse: WriteResponse{
+						ColumnDelimiter: Comma,
+						LineEnding:      Linefeed,
+					},
+				},
+				fields: []string{
+					"Name",
+					"Site",
+					"Phone",
+				},
+				sb: &strings.Builder{},
+			},
+			args: args{
+				records: []Record{
+					&testRecord{
+						fields: map[string]interface{}{
+							"Name": "name 1",
+							"Site": FieldNull,
+						},
+					},
+					&testRecord{
+						fields: map[string]interface{}{
+							"Name": "name 2",
+						},
+						insertNull: true,
+					},
+				},
+			},
+			want:    "Name,Site,Phone\nname 1,#N/A,\nname 2,#N/A,#N/A\n",
+			wantErr: false,
+		},
 		{
 			name: "Adding",
 			fields: fields{
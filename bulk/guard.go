@@ -0,0 +1,63 @@
+package bulk
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrDuplicateJob is returned when a job is created with a client request ID
+// that the resource's guard has already seen.
+var ErrDuplicateJob = errors.New("bulk job: a job was already created with the client request id")
+
+// JobGuard refuses the creation of a second job with the same client request ID,
+// since Salesforce does not remove duplicate jobs.  A guard can be shared by the
+// processes that create jobs, like with a database or a cache.
+//
+// Claim records the key and returns false if the key was already claimed.
+//
+// Release removes the key, so a job whose creation failed can be created again.
+type JobGuard interface {
+	Claim(key string) bool
+	Release(key string)
+}
+
+// NewMemoryGuard returns a JobGuard that keeps the keys in memory for the window.
+func NewMemoryGuard(window time.Duration) JobGuard {
+	return &memoryGuard{
+		window: window,
+		claims: make(map[string]time.Time),
+		now:    time.Now,
+	}
+}
+
+type memoryGuard struct {
+	mu     sync.Mutex
+	window time.Duration
+	claims map[string]time.Time
+	now    func() time.Time
+}
+
+func (g *memoryGuard) Claim(key string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	for claimed, at := range g.claims {
+		if now.Sub(at) >= g.window {
+			delete(g.claims, claimed)
+		}
+	}
+	if _, has := g.claims[key]; has {
+		return false
+	}
+	g.claims[key] = now
+	return true
+}
+
+func (g *memoryGuard) Release(key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.claims, key)
+}
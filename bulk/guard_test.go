@@ -0,0 +1,73 @@
+package bulk
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryGuard(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	guard := NewMemoryGuard(time.Hour).(*memoryGuard)
+	guard.now = func() time.Time {
+		return now
+	}
+
+	if guard.Claim("load-1") == false {
+		t.Errorf("memoryGuard.Claim() = false, want true")
+	}
+	if guard.Claim("load-1") {
+		t.Errorf("memoryGuard.Claim() = true, want false for a claimed key")
+	}
+	if guard.Claim("load-2") == false {
+		t.Errorf("memoryGuard.Claim() = false, want true for another key")
+	}
+
+	guard.Release("load-2")
+	if guard.Claim("load-2") == false {
+		t.Errorf("memoryGuard.Claim() = false, want true for a released key")
+	}
+
+	now = now.Add(time.Hour)
+	if guard.Claim("load-1") == false {
+		t.Errorf("memoryGuard.Claim() = false, want true after the window")
+	}
+}
+
+func TestResource_CreateJob_Guard(t *testing.T) {
+	client, _ := mockRunJobsClient(t, "")
+	r := &Resource{
+		session: &mockSessionFormatter{
+			url:    "https://test.salesforce.com",
+			client: client,
+		},
+		Guard: NewMemoryGuard(time.Hour),
+	}
+
+	failed := Options{
+		Operation:       Insert,
+		ClientRequestID: "load-1",
+	}
+	if _, err := r.CreateJob(failed); err == nil || errors.Is(err, ErrDuplicateJob) {
+		t.Errorf("Resource.CreateJob() error = %v, want a validation error", err)
+		return
+	}
+
+	options := Options{
+		Object:          "Account",
+		Operation:       Insert,
+		ClientRequestID: "load-1",
+	}
+	if _, err := r.CreateJob(options); err != nil {
+		t.Errorf("Resource.CreateJob() error = %v, want the job after a failed creation", err)
+		return
+	}
+	if _, err := r.CreateJob(options); errors.Is(err, ErrDuplicateJob) == false {
+		t.Errorf("Resource.CreateJob() error = %v, want %v", err, ErrDuplicateJob)
+	}
+
+	options.ClientRequestID = ""
+	if _, err := r.CreateJob(options); err != nil {
+		t.Errorf("Resource.CreateJob() error = %v, want the job without a client request id", err)
+	}
+}
@@ -1,16 +1,24 @@
 package bulk
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/enrique-esquivel/go-sfdc/internal/csvstruct"
 	"github.com/enrique-esquivel/go-sfdc/session"
 )
 
@@ -44,6 +52,12 @@ const (
 	Tab ColumnDelimiter = "TAB"
 )
 
+// Rune returns the character of the column delimiter.  An empty or unknown
+// delimiter is a comma, which is the Salesforce default.
+func (d ColumnDelimiter) Rune() rune {
+	return csvstruct.Delimiter(string(d))
+}
+
 // ContentType is the format of the data being processed.
 type ContentType string
 
@@ -84,6 +98,8 @@ const (
 	Open State = "Open"
 	// UpdateComplete all data for the job has been uploaded and the job is ready to be queued and processed.
 	UpdateComplete State = "UploadComplete"
+	// InProgress the job is being processed by Salesforce.
+	InProgress State = "InProgress"
 	// Aborted the job has been aborted.
 	Aborted State = "Aborted"
 	// JobComplete the job was processed by Salesforce.
@@ -133,13 +149,25 @@ type FailedRecord struct {
 // ContentType is the content type for the job.  This field is optional.
 //
 // ExternalIDFieldName is the external ID field in the object being updated.  Only needed for
-// upsert operations.  This field is required for upsert operations.
+// upsert operations.  This field is required for upsert operations, and is removed for
+// the other operations.
 //
 // LineEnding is the line ending used for the CSV job data.  This field is optional.
 //
 // Object is the object type for the data bneing processed. This field is required.
 //
 // Operation is the processing operation for the job. This field is required.
+//
+// JobType is the type of the job, V2Ingest or BigObjectIngest.  Big objects, whose
+// names end with __b, are loaded with a BigObjectIngest job, which only supports the
+// insert operation.  This field is optional and defaults to V2Ingest.
+//
+// ClientRequestID is the key that the resource's guard uses to refuse duplicate
+// jobs, and is not sent to Salesforce.  This field is optional.
+//
+// Fields are the columns of the job data that UploadFromChannel writes, and are not
+// sent to Salesforce.  This field is optional and defaults to the fields of the first
+// record.
 type Options struct {
 	ColumnDelimiter     ColumnDelimiter `json:"columnDelimiter"`
 	ContentType         ContentType     `json:"contentType"`
@@ -147,11 +175,14 @@ type Options struct {
 	LineEnding          LineEnding      `json:"lineEnding"`
 	Object              string          `json:"object"`
 	Operation           Operation       `json:"operation"`
+	JobType             JobType         `json:"jobType,omitempty"`
+	ClientRequestID     string          `json:"-"`
+	Fields              []string        `json:"-"`
 }
 
 // WriteResponse is the response to job APIs.
 type WriteResponse struct {
-	APIVersion          float32         `json:"apiVersion"`
+	APIVersion          sfdc.APIVersion `json:"apiVersion"`
 	ColumnDelimiter     ColumnDelimiter `json:"columnDelimiter"`
 	ConcurrencyMode     string          `json:"concurrencyMode"`
 	ContentType         string          `json:"contentType"`
@@ -180,12 +211,92 @@ type Info struct {
 	ErrorMessage            string `json:"errorMessage"`
 }
 
-// Job is the bulk job.
+// ParseOptions are the options used when requesting and parsing the job results.
+//
+// KeepMetaColumns will keep the sf__Id, sf__Created and sf__Error columns in the
+// record fields.
+//
+// Accept is the Accept header of the results requests, which defaults to text/csv.
+//
+// LazyQuotes allows quotes in unquoted fields and unescaped quotes in quoted fields,
+// which Salesforce can return in the field values.
+//
+// FieldsPerRecord is the number of fields of every row.  If zero, the rows must have
+// as many fields as the header, and if negative, the rows can have any number of fields.
+//
+// CollectErrors continues the parse when a row can not be parsed, and the rows that
+// could not be parsed are returned as ParseErrors along with the parsed records.
+//
+// CSVReader is called with the CSV reader of the results, after the column delimiter
+// and the options are set, so the reader can be configured further.
+//
+// ResultsReadyTimeout is how long the results are retried when Salesforce returns
+// not found for a job that has completed, which can happen right after the job
+// reports that it is complete.  If zero, DefaultResultsReadyTimeout is used.
+type ParseOptions struct {
+	KeepMetaColumns     bool
+	Accept              string
+	LazyQuotes          bool
+	FieldsPerRecord     int
+	CollectErrors       bool
+	CSVReader           func(*csv.Reader)
+	ResultsReadyTimeout time.Duration
+}
+
+const defaultResultsAccept = "text/csv"
+
+func (j *Job) accept() string {
+	if j.ParseOptions.Accept != "" {
+		return j.ParseOptions.Accept
+	}
+	return defaultResultsAccept
+}
+
+func (j *Job) csvReader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.Comma = j.delimiter()
+	reader.LazyQuotes = j.ParseOptions.LazyQuotes
+	reader.FieldsPerRecord = j.ParseOptions.FieldsPerRecord
+	if j.ParseOptions.CSVReader != nil {
+		j.ParseOptions.CSVReader(reader)
+	}
+	return reader
+}
+
+// Job is the bulk job.  A Job is not safe for concurrent use, since Info, Close,
+// Abort and the waits for the job to complete update the state of its WriteResponse.
+//
+// UploadLimit is the most bytes that Upload will send, which can be set to
+// UploadSizeLimit to enforce the Salesforce limit before the data is rejected.
+// If zero, the upload is not limited.
 type Job struct {
 	session       session.ServiceFormatter
 	WriteResponse WriteResponse
+	ParseOptions  ParseOptions
+	UploadLimit   int64
+	records       *recordCounts
 }
 
+// recordCounts are the record counts of the job information that was last
+// retrieved, which are traced with the information and results requests.
+type recordCounts struct {
+	processed int
+	failed    int
+}
+
+// The limits of the job data.
+const (
+	// UploadSizeLimit is the largest job data that Salesforce accepts.
+	UploadSizeLimit int64 = 150 * 1024 * 1024
+	// RecommendedUploadSize is the job data size that Salesforce recommends, to
+	// allow for the data growing when it is encoded.
+	RecommendedUploadSize int64 = 100 * 1024 * 1024
+)
+
+// ErrUploadTooLarge is returned by Upload when the job data is larger than the
+// job's UploadLimit.
+var ErrUploadTooLarge = errors.New("bulk job: job data is too large")
+
 func (j *Job) create(options Options) error {
 	err := j.formatOptions(&options)
 	if err != nil {
@@ -193,33 +304,92 @@ func (j *Job) create(options Options) error {
 	}
 	j.WriteResponse, err = j.createCallout(options)
 	if err != nil {
-		return err
+		return jobError(options.Object, options.Operation, err)
 	}
 
 	return nil
 }
 
-func (j *Job) formatOptions(options *Options) error {
-	if options.Operation == "" {
-		return errors.New("bulk job: operation is required")
+// jobError adds the object and operation of the job to the error, like
+// bulk job [Account upsert]: ..., so the failed job can be found when many jobs
+// run at once.
+func jobError(object string, operation Operation, err error) error {
+	return fmt.Errorf("bulk job [%s %s]: %w", object, operation, err)
+}
+
+// Validate checks that the options are able to create a job, without
+// calling out to Salesforce.
+func (o Options) Validate() error {
+	if errs := o.validate(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ValidateAll checks the options like Validate, but returns all of the problems
+// as sfdc.ValidationErrors instead of only the first one.
+func (o Options) ValidateAll() error {
+	return o.validate().Err()
+}
+
+func (o Options) validate() sfdc.ValidationErrors {
+	var errs sfdc.ValidationErrors
+	if o.Operation == "" {
+		errs = append(errs, errors.New("bulk job: operation is required"))
 	}
-	if options.Operation == Upsert {
-		if options.ExternalIDFieldName == "" {
-			return errors.New("bulk job: external id field name is required for upsert operation")
+	if o.Operation == Upsert {
+		if o.ExternalIDFieldName == "" {
+			errs = append(errs, errors.New("bulk job: external id field name is required for upsert operation"))
 		}
 	}
-	if options.Object == "" {
-		return errors.New("bulk job: object is required")
+	if o.Object == "" {
+		errs = append(errs, errors.New("bulk job: object is required"))
 	}
-	if options.LineEnding == "" {
-		options.LineEnding = Linefeed
+	switch o.JobType {
+	case "", V2Ingest:
+	case BigObjects:
+		if o.Operation != Insert {
+			errs = append(errs, errors.New("bulk job: big object jobs only support the insert operation"))
+		}
+		if strings.HasSuffix(o.Object, bigObjectSuffix) == false {
+			errs = append(errs, fmt.Errorf("bulk job: %s is not a big object", o.Object))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("bulk job: %s is not a bulk 2.0 ingest job type", o.JobType))
+	}
+	return errs
+}
+
+const bigObjectSuffix = "__b"
+
+// Normalize applies the defaults to the options that are not set, which are the
+// options that will be submitted when the job is created.  The line ending defaults
+// to LF, the content type to CSV, the column delimiter to COMMA and the job type to V2Ingest.
+// The external ID field name is removed when the operation is not upsert, since
+// Salesforce only uses it to match the records of an upsert.
+func (o *Options) Normalize() {
+	if o.Operation != Upsert {
+		o.ExternalIDFieldName = ""
 	}
-	if options.ContentType == "" {
-		options.ContentType = CSV
+	if o.LineEnding == "" {
+		o.LineEnding = Linefeed
 	}
-	if options.ColumnDelimiter == "" {
-		options.ColumnDelimiter = Comma
+	if o.ContentType == "" {
+		o.ContentType = CSV
+	}
+	if o.ColumnDelimiter == "" {
+		o.ColumnDelimiter = Comma
+	}
+	if o.JobType == "" {
+		o.JobType = V2Ingest
+	}
+}
+
+func (j *Job) formatOptions(options *Options) error {
+	if err := options.Validate(); err != nil {
+		return err
 	}
+	options.Normalize()
 	return nil
 }
 
@@ -236,6 +406,10 @@ func (j *Job) createCallout(options Options) (WriteResponse, error) {
 	request.Header.Add("Accept", "application/json")
 	request.Header.Add("Content-Type", "application/json")
 	j.session.AuthorizationHeader(request)
+	request = sfdc.WithTraceAttributes(request, map[string]string{
+		sfdc.TraceObject:    options.Object,
+		sfdc.TraceOperation: string(options.Operation),
+	})
 
 	return j.response(request)
 }
@@ -261,9 +435,25 @@ func (j *Job) response(request *http.Request) (WriteResponse, error) {
 	return value, nil
 }
 
-// Info returns the current job information.
+// Info returns the current job information.  The state of the job's WriteResponse
+// is updated with the state that is returned, which is the state that CanUpload checks.
 func (j *Job) Info() (Info, error) {
-	return j.fetchInfo(j.WriteResponse.ID)
+	info, err := j.fetchInfo(j.WriteResponse.ID)
+	if err != nil {
+		return Info{}, err
+	}
+	j.WriteResponse.State = info.State
+	return info, nil
+}
+
+// CanUpload returns true when the job's data can still be uploaded, which is while
+// the job is open.  The state is the one that was last returned by Salesforce when
+// the job was created, retrieved, closed, aborted or its information was fetched, so
+// the information should be fetched first to know the current state.  After an
+// interrupted upload, a job that can not be uploaded should not be uploaded again,
+// since its data may already be processed, and a new job should be created instead.
+func (j *Job) CanUpload() bool {
+	return j.WriteResponse.State == Open
 }
 
 func (j *Job) fetchInfo(id string) (Info, error) {
@@ -275,6 +465,11 @@ func (j *Job) fetchInfo(id string) (Info, error) {
 	request.Header.Add("Accept", "application/json")
 	request.Header.Add("Content-Type", "application/json")
 	j.session.AuthorizationHeader(request)
+	request = sfdc.WithTraceAttributes(request, j.traceAttributes())
+	request = sfdc.WithTraceAttributes(request, j.recordAttributes())
+	request = sfdc.WithTraceAttributes(request, map[string]string{
+		sfdc.TraceJobID: id,
+	})
 
 	return j.infoResponse(request)
 }
@@ -297,6 +492,10 @@ func (j *Job) infoResponse(request *http.Request) (Info, error) {
 	if err != nil {
 		return Info{}, err
 	}
+	j.records = &recordCounts{
+		processed: value.NumberRecordsProcessed,
+		failed:    value.NumberRecordsFailed,
+	}
 	return value, nil
 }
 
@@ -318,8 +517,14 @@ func (j *Job) setState(state State) (WriteResponse, error) {
 	request.Header.Add("Accept", "application/json")
 	request.Header.Add("Content-Type", "application/json")
 	j.session.AuthorizationHeader(request)
+	request = sfdc.WithTraceAttributes(request, j.traceAttributes())
 
-	return j.response(request)
+	response, err := j.response(request)
+	if err != nil {
+		return WriteResponse{}, err
+	}
+	j.WriteResponse.State = response.State
+	return response, nil
 }
 
 // Close will close the current job.
@@ -340,29 +545,65 @@ func (j *Job) Delete() error {
 		return err
 	}
 	j.session.AuthorizationHeader(request)
+	request = sfdc.WithTraceAttributes(request, j.traceAttributes())
 
 	response, err := j.session.Client().Do(request)
 	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return errors.New("job error: unable to delete job")
+		return sfdc.HandleError(response)
 	}
 	return nil
 }
 
-// Upload will upload data to processing.
+// ContentURL returns the URL the job data is uploaded to.  The content URL
+// returned by Salesforce, which is relative to the instance, is preferred over
+// the batches URL of the job.
+func (j *Job) ContentURL() string {
+	if contentURL := j.WriteResponse.ContentURL; contentURL != "" {
+		return strings.TrimSuffix(j.session.InstanceURL(), "/") + "/" + strings.TrimPrefix(contentURL, "/")
+	}
+	return j.session.ServiceURL() + bulk2Endpoint + "/" + j.WriteResponse.ID + "/batches"
+}
+
+// Upload will upload data to processing.  If the job has an UploadLimit and the
+// data is larger, the upload is stopped and ErrUploadTooLarge is returned, and the
+// data should be split into several jobs.
 func (j *Job) Upload(body io.Reader) error {
-	url := j.session.ServiceURL() + bulk2Endpoint + "/" + j.WriteResponse.ID + "/batches"
-	request, err := http.NewRequest(http.MethodPut, url, body)
+	if err := j.upload(context.Background(), body); err != nil {
+		return jobError(j.WriteResponse.Object, j.WriteResponse.Operation, err)
+	}
+	return nil
+}
+
+func (j *Job) upload(ctx context.Context, body io.Reader) error {
+	var limited *limitedReader
+	if j.UploadLimit > 0 && body != nil {
+		limited = &limitedReader{
+			reader: body,
+			limit:  j.UploadLimit,
+		}
+		body = limited
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, j.ContentURL(), body)
 	if err != nil {
 		return err
 	}
 	request.Header.Add("Content-Type", "text/csv")
 	j.session.AuthorizationHeader(request)
+	request = sfdc.WithTraceAttributes(request, j.traceAttributes())
 
 	response, err := j.session.Client().Do(request)
+	if limited != nil && limited.hasExceeded() {
+		if err == nil {
+			response.Body.Close()
+		}
+		return fmt.Errorf("%w: more than %d bytes, split the data into jobs of at most %d bytes", ErrUploadTooLarge, j.UploadLimit, RecommendedUploadSize)
+	}
 	if err != nil {
 		return err
 	}
@@ -374,147 +615,318 @@ func (j *Job) Upload(body io.Reader) error {
 	return nil
 }
 
-func (j *Job) getSuccessfulResults() (*http.Response, error) {
-	url := j.session.ServiceURL() + bulk2Endpoint + "/" + j.WriteResponse.ID + "/successfulResults/"
-	request, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
+// limitedReader reads until the limit is exceeded, then fails the read so the
+// request is not sent.  The transport reads the body in its own goroutine, which
+// can still be reading when the response is returned, so exceeded is atomic.
+type limitedReader struct {
+	reader   io.Reader
+	limit    int64
+	read     int64
+	exceeded int32
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.reader.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		atomic.StoreInt32(&l.exceeded, 1)
+		return 0, ErrUploadTooLarge
 	}
-	request.Header.Add("Accept", "text/csv")
-	j.session.AuthorizationHeader(request)
+	return n, err
+}
 
-	response, err := j.session.Client().Do(request)
+func (l *limitedReader) hasExceeded() bool {
+	return atomic.LoadInt32(&l.exceeded) == 1
+}
+
+// SuccessfulResultsReader returns the successful results as they are returned
+// by Salesforce, so they can be streamed anywhere.  The caller is responsible
+// for closing the reader.
+func (j *Job) SuccessfulResultsReader() (io.ReadCloser, error) {
+	response, err := j.getResults(successfulResults)
 	if err != nil {
 		return nil, err
 	}
+	return response.Body, nil
+}
 
-	if response.StatusCode != http.StatusOK {
-		defer response.Body.Close()
-		return nil, sfdc.HandleError(response)
+// ReadSuccessfulResults read job results from local file.  A gzip compressed
+// file is decompressed.
+func (j *Job) ReadSuccessfulResults(filename string) ([]SuccessfulRecord, error) {
+	f, err := openResults(filename)
+	if err != nil {
+		return nil, err
 	}
+	defer f.Close()
 
-	return response, nil
+	return j.ParseSuccessfulResults(f)
 }
 
-// ReadSuccessfulResults read job results from local file
-func (j *Job) ReadSuccessfulResults(filename string) ([]SuccessfulRecord, error) {
+// openResults opens a local results file, decompressing it when the file
+// starts with the gzip magic bytes.
+func openResults(filename string) (io.ReadCloser, error) {
 	f, err := os.Open(filename)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
 
-	return j.ParseSuccessfulResults(f)
+	buffered := bufio.NewReader(f)
+	magic, err := buffered.Peek(len(gzipMagic))
+	if err != nil && err != io.EOF {
+		f.Close()
+		return nil, err
+	}
+	if bytes.Equal(magic, gzipMagic) == false {
+		return &resultsFile{
+			Reader: buffered,
+			file:   f,
+		}, nil
+	}
+
+	reader, err := gzip.NewReader(buffered)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &resultsFile{
+		Reader: reader,
+		file:   f,
+	}, nil
+}
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+type resultsFile struct {
+	io.Reader
+	file *os.File
+}
+
+func (r *resultsFile) Close() error {
+	if closer, ok := r.Reader.(io.Closer); ok {
+		closer.Close()
+	}
+	return r.file.Close()
 }
 
 // ParseSuccessfulResults parse results of operation
 func (j *Job) ParseSuccessfulResults(stream io.Reader) ([]SuccessfulRecord, error) {
-	reader := csv.NewReader(stream)
-	reader.Comma = j.delimiter()
+	reader := j.csvReader(stream)
 
 	var records []SuccessfulRecord
 	fields, err := reader.Read()
 	if err != nil {
 		return nil, err
 	}
-	for {
+	positions, err := j.metaPositions(fields, sfCreated, sfID)
+	if err != nil {
+		return nil, err
+	}
+	createdPos, idPos := positions[0], positions[1]
+	var parseErrs ParseErrors
+	for row := 1; ; row++ {
 		values, err := reader.Read()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return nil, err
+			if err := j.readError(&parseErrs, row, err); err != nil {
+				return nil, err
+			}
+			continue
 		}
 		var record SuccessfulRecord
-		created, err := strconv.ParseBool(values[j.headerPosition(sfCreated, fields)])
+		created, err := strconv.ParseBool(value(values, createdPos))
 		if err != nil {
-			return nil, err
+			if err := j.rowError(&parseErrs, row, err); err != nil {
+				return nil, err
+			}
+			continue
 		}
 		record.Created = created
-		record.ID = values[j.headerPosition(sfID, fields)]
-		record.Fields = j.record(fields[2:], values[2:])
+		record.ID = value(values, idPos)
+		record.Fields = j.resultRecord(fields, values)
 		records = append(records, record)
 	}
 
-	return records, nil
+	return records, parseErrs.Err()
 }
 
 // SuccessfulRecords returns the successful records for the job.
 func (j *Job) SuccessfulRecords() ([]SuccessfulRecord, error) {
-	response, err := j.getSuccessfulResults()
+	results, err := j.SuccessfulResultsReader()
 	if err != nil {
 		return nil, err
 	}
 
-	defer response.Body.Close()
-	return j.ParseSuccessfulResults(response.Body)
+	defer results.Close()
+	return j.ParseSuccessfulResults(results)
 }
 
-// ExportSuccessfulResults export failed results to file.
+// ExportSuccessfulResults export successful results to file.  The file is only
+// created once Salesforce returns the results, and is removed if the results can
+// not be written to it.
 func (j *Job) ExportSuccessfulResults(filename string) error {
-	response, err := j.getSuccessfulResults()
+	results, err := j.SuccessfulResultsReader()
 	if err != nil {
 		return err
 	}
+	return exportResults(filename, results)
+}
 
-	defer response.Body.Close()
-
-	out, err := os.Create(filename)
+// WriteSuccessfulResults writes the successful results to the writer.
+func (j *Job) WriteSuccessfulResults(w io.Writer) error {
+	results, err := j.SuccessfulResultsReader()
 	if err != nil {
 		return err
 	}
 
-	defer out.Close()
+	defer results.Close()
 
-	_, err = io.Copy(out, response.Body)
+	_, err = io.Copy(w, results)
 	return err
 }
 
-func (j *Job) getFailedResults() (*http.Response, error) {
-	url := j.session.ServiceURL() + bulk2Endpoint + "/" + j.WriteResponse.ID + "/failedResults/"
-	request, err := http.NewRequest(http.MethodGet, url, nil)
+// FailedResultsReader returns the failed results as they are returned by
+// Salesforce, so they can be streamed anywhere.  The caller is responsible
+// for closing the reader.
+func (j *Job) FailedResultsReader() (io.ReadCloser, error) {
+	response, err := j.getResults(failedResults)
 	if err != nil {
 		return nil, err
 	}
-	request.Header.Add("Accept", "text/csv")
-	j.session.AuthorizationHeader(request)
+	return response.Body, nil
+}
 
-	response, err := j.session.Client().Do(request)
+// DefaultResultsReadyTimeout is how long the job results are retried when the
+// ParseOptions do not have a ResultsReadyTimeout.
+const DefaultResultsReadyTimeout = 30 * time.Second
+
+var resultsReadyBackoff = 250 * time.Millisecond
+
+func (j *Job) resultsReadyTimeout() time.Duration {
+	if j.ParseOptions.ResultsReadyTimeout > 0 {
+		return j.ParseOptions.ResultsReadyTimeout
+	}
+	return DefaultResultsReadyTimeout
+}
+
+func (j *Job) getResults(resource string) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), j.resultsReadyTimeout())
+	defer cancel()
+
+	backoff := resultsReadyBackoff
+	for {
+		response, err := j.resultsCallout(resource)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.StatusCode == http.StatusOK {
+			return response, nil
+		}
+		err = sfdc.HandleError(response)
+		response.Body.Close()
+		if response.StatusCode != http.StatusNotFound || j.complete() == false {
+			return nil, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// The results resources of a job.
+const (
+	successfulResults  = "successfulResults"
+	failedResults      = "failedResults"
+	unprocessedRecords = "unprocessedrecords"
+)
+
+// resultsURL returns the URL of the job's results resource.  The URL always ends
+// with a slash, which is how Salesforce documents the results resources and is
+// accepted by all of the API versions, where some versions do not find the
+// resource without it.
+func (j *Job) resultsURL(resource string) string {
+	return j.session.ServiceURL() + bulk2Endpoint + "/" + j.WriteResponse.ID + "/" + strings.Trim(resource, "/") + "/"
+}
+
+func (j *Job) resultsCallout(resource string) (*http.Response, error) {
+	url := j.resultsURL(resource)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
+	request.Header.Add("Accept", j.accept())
+	j.session.AuthorizationHeader(request)
+	request = sfdc.WithTraceAttributes(request, j.traceAttributes())
+	request = sfdc.WithTraceAttributes(request, j.recordAttributes())
 
-	if response.StatusCode != http.StatusOK {
-		defer response.Body.Close()
-		return nil, sfdc.HandleError(response)
-	}
+	return j.session.Client().Do(request)
+}
 
-	return response, nil
+// complete checks if the job has completed, so a not found result is the
+// results not being ready yet.
+func (j *Job) complete() bool {
+	info, err := j.Info()
+	return err == nil && info.State == JobComplete
 }
 
-// ExportFailedResults export failed results to file.
+// ExportFailedResults export failed results to file.  The file is only created
+// once Salesforce returns the results, and is removed if the results can not be
+// written to it.
 func (j *Job) ExportFailedResults(filename string) error {
-	response, err := j.getFailedResults()
+	results, err := j.FailedResultsReader()
 	if err != nil {
 		return err
 	}
+	return exportResults(filename, results)
+}
 
-	defer response.Body.Close()
+// exportResults writes the results to the file and closes the results.  A file
+// that the results could not be written to is removed, so an error does not leave
+// an empty or partial file behind.
+func exportResults(filename string, results io.ReadCloser) error {
+	defer results.Close()
 
 	out, err := os.Create(filename)
 	if err != nil {
 		return err
 	}
 
-	defer out.Close()
+	_, err = io.Copy(out, results)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filename)
+		return err
+	}
+	return nil
+}
+
+// WriteFailedResults writes the failed results to the writer.
+func (j *Job) WriteFailedResults(w io.Writer) error {
+	results, err := j.FailedResultsReader()
+	if err != nil {
+		return err
+	}
+
+	defer results.Close()
 
-	_, err = io.Copy(out, response.Body)
+	_, err = io.Copy(w, results)
 	return err
 }
 
-// ReadFailedResults read job results from local file
+// ReadFailedResults read job results from local file.  A gzip compressed
+// file is decompressed.
 func (j *Job) ReadFailedResults(filename string) ([]FailedRecord, error) {
-	f, err := os.Open(filename)
+	f, err := openResults(filename)
 	if err != nil {
 		return nil, err
 	}
@@ -525,53 +937,62 @@ func (j *Job) ReadFailedResults(filename string) ([]FailedRecord, error) {
 
 // ParseFailedResults parse response from failedresults
 func (j *Job) ParseFailedResults(stream io.Reader) ([]FailedRecord, error) {
-	reader := csv.NewReader(stream)
-	reader.Comma = j.delimiter()
+	reader := j.csvReader(stream)
 
 	var records []FailedRecord
 	fields, err := reader.Read()
 	if err != nil {
 		return nil, err
 	}
-	for {
+	positions, err := j.metaPositions(fields, sfError, sfID)
+	if err != nil {
+		return nil, err
+	}
+	errorPos, idPos := positions[0], positions[1]
+	var parseErrs ParseErrors
+	for row := 1; ; row++ {
 		values, err := reader.Read()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return nil, err
+			if err := j.readError(&parseErrs, row, err); err != nil {
+				return nil, err
+			}
+			continue
 		}
 		var record FailedRecord
-		record.Error = values[j.headerPosition(sfError, fields)]
-		record.ID = values[j.headerPosition(sfID, fields)]
-		record.Fields = j.record(fields[2:], values[2:])
+		record.Error = value(values, errorPos)
+		record.ID = value(values, idPos)
+		record.Fields = j.resultRecord(fields, values)
 		records = append(records, record)
 	}
 
-	return records, nil
+	return records, parseErrs.Err()
 }
 
 // FailedRecords returns the failed records for the job.
 func (j *Job) FailedRecords() ([]FailedRecord, error) {
-	response, err := j.getFailedResults()
+	results, err := j.FailedResultsReader()
 	if err != nil {
 		return nil, err
 	}
 
-	defer response.Body.Close()
+	defer results.Close()
 
-	return j.ParseFailedResults(response.Body)
+	return j.ParseFailedResults(results)
 }
 
 // UnprocessedRecords returns the unprocessed records for the job.
 func (j *Job) UnprocessedRecords() ([]UnprocessedRecord, error) {
-	url := j.session.ServiceURL() + bulk2Endpoint + "/" + j.WriteResponse.ID + "/unprocessedrecords/"
+	url := j.resultsURL(unprocessedRecords)
 	request, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
-	request.Header.Add("Accept", "text/csv")
+	request.Header.Add("Accept", j.accept())
 	j.session.AuthorizationHeader(request)
+	request = sfdc.WithTraceAttributes(request, j.traceAttributes())
 
 	response, err := j.session.Client().Do(request)
 	if err != nil {
@@ -583,28 +1004,31 @@ func (j *Job) UnprocessedRecords() ([]UnprocessedRecord, error) {
 		return nil, sfdc.HandleError(response)
 	}
 
-	reader := csv.NewReader(response.Body)
-	reader.Comma = j.delimiter()
+	reader := j.csvReader(response.Body)
 
 	var records []UnprocessedRecord
 	fields, err := reader.Read()
 	if err != nil {
 		return nil, err
 	}
-	for {
+	var parseErrs ParseErrors
+	for row := 1; ; row++ {
 		values, err := reader.Read()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return nil, err
+			if err := j.readError(&parseErrs, row, err); err != nil {
+				return nil, err
+			}
+			continue
 		}
 		var record UnprocessedRecord
 		record.Fields = j.record(fields, values)
 		records = append(records, record)
 	}
 
-	return records, nil
+	return records, parseErrs.Err()
 }
 
 func (j *Job) headerPosition(column string, header []string) int {
@@ -616,33 +1040,77 @@ func (j *Job) headerPosition(column string, header []string) int {
 	return -1
 }
 
+// metaPositions returns the header position of each meta column, wherever Salesforce
+// placed it in the results.
+func (j *Job) metaPositions(header []string, columns ...string) ([]int, error) {
+	positions := make([]int, len(columns))
+	for idx, column := range columns {
+		positions[idx] = j.headerPosition(column, header)
+		if positions[idx] == -1 {
+			return nil, fmt.Errorf("bulk job: results are missing the %s column", column)
+		}
+	}
+	return positions, nil
+}
+
 func (j *Job) fields(header []string, offset int) []string {
 	fields := make([]string, len(header)-offset)
 	copy(fields[:], header[offset:])
 	return fields
 }
 
+func (j *Job) resultRecord(fields, values []string) map[string]string {
+	if j.ParseOptions.KeepMetaColumns {
+		return j.record(fields, values)
+	}
+	record := make(map[string]string)
+	for idx, field := range fields {
+		switch field {
+		case sfID, sfCreated, sfError:
+		default:
+			record[field] = value(values, idx)
+		}
+	}
+	return record
+}
+
 func (j *Job) record(fields, values []string) map[string]string {
 	record := make(map[string]string)
 	for idx, field := range fields {
-		record[field] = values[idx]
+		record[field] = value(values, idx)
 	}
 	return record
 }
 
+// value returns the value at the position, or empty if the row is shorter than
+// the header, which the CSV reader allows when it is configured to.
+func value(values []string, idx int) string {
+	if idx < len(values) {
+		return values[idx]
+	}
+	return ""
+}
+
 func (j *Job) delimiter() rune {
-	switch ColumnDelimiter(j.WriteResponse.ColumnDelimiter) {
-	case Tab:
-		return '\t'
-	case SemiColon:
-		return ';'
-	case Pipe:
-		return '|'
-	case Caret:
-		return '^'
-	case Backquote:
-		return '`'
-	default:
-		return ','
+	return j.WriteResponse.ColumnDelimiter.Rune()
+}
+
+func (j *Job) traceAttributes() map[string]string {
+	return map[string]string{
+		sfdc.TraceObject:    j.WriteResponse.Object,
+		sfdc.TraceOperation: string(j.WriteResponse.Operation),
+		sfdc.TraceJobID:     j.WriteResponse.ID,
+	}
+}
+
+// recordAttributes are the trace attributes of the record counts, which are empty
+// until the job information is retrieved.
+func (j *Job) recordAttributes() map[string]string {
+	if j.records == nil {
+		return nil
+	}
+	return map[string]string{
+		sfdc.TraceRecordsProcessed: strconv.Itoa(j.records.processed),
+		sfdc.TraceRecordsFailed:    strconv.Itoa(j.records.failed),
 	}
 }
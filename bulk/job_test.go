@@ -1,13 +1,22 @@
 package bulk
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/enrique-esquivel/go-sfdc"
 	"github.com/enrique-esquivel/go-sfdc/session"
 )
 
@@ -40,12 +49,12 @@ func TestJob_formatOptions(t *testing.T) {
 				},
 			},
 			want: &Options{
-				ColumnDelimiter:     Caret,
-				ContentType:         CSV,
-				ExternalIDFieldName: "Some External Field",
-				LineEnding:          Linefeed,
-				Object:              "Account",
-				Operation:           Insert,
+				ColumnDelimiter: Caret,
+				ContentType:     CSV,
+				LineEnding:      Linefeed,
+				Object:          "Account",
+				Operation:       Insert,
+				JobType:         V2Ingest,
 			},
 			wantErr: false,
 		},
@@ -60,12 +69,12 @@ func TestJob_formatOptions(t *testing.T) {
 				},
 			},
 			want: &Options{
-				ColumnDelimiter:     Comma,
-				ContentType:         CSV,
-				ExternalIDFieldName: "Some External Field",
-				LineEnding:          Linefeed,
-				Object:              "Account",
-				Operation:           Insert,
+				ColumnDelimiter: Comma,
+				ContentType:     CSV,
+				LineEnding:      Linefeed,
+				Object:          "Account",
+				Operation:       Insert,
+				JobType:         V2Ingest,
 			},
 			wantErr: false,
 		},
@@ -124,6 +133,231 @@ func TestJob_formatOptions(t *testing.T) {
 	}
 }
 
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		wantErr bool
+	}{
+		{
+			name: "passing",
+			options: Options{
+				Object:    "Account",
+				Operation: Insert,
+			},
+			wantErr: false,
+		},
+		{
+			name: "no object",
+			options: Options{
+				Operation: Insert,
+			},
+			wantErr: true,
+		},
+		{
+			name: "no operation",
+			options: Options{
+				Object: "Account",
+			},
+			wantErr: true,
+		},
+		{
+			name: "no external fields",
+			options: Options{
+				Object:    "Account",
+				Operation: Upsert,
+			},
+			wantErr: true,
+		},
+		{
+			name: "big object",
+			options: Options{
+				Object:    "Customer_Interaction__b",
+				Operation: Insert,
+				JobType:   BigObjects,
+			},
+			wantErr: false,
+		},
+		{
+			name: "big object not insert",
+			options: Options{
+				Object:    "Customer_Interaction__b",
+				Operation: Delete,
+				JobType:   BigObjects,
+			},
+			wantErr: true,
+		},
+		{
+			name: "big object not a big object",
+			options: Options{
+				Object:    "Account",
+				Operation: Insert,
+				JobType:   BigObjects,
+			},
+			wantErr: true,
+		},
+		{
+			name: "classic job type",
+			options: Options{
+				Object:    "Account",
+				Operation: Insert,
+				JobType:   Classic,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.options.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Options.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_ValidateAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		wantErr string
+	}{
+		{
+			name: "passing",
+			options: Options{
+				Object:    "Account",
+				Operation: Insert,
+			},
+			wantErr: "",
+		},
+		{
+			name:    "no object or operation",
+			options: Options{},
+			wantErr: "bulk job: operation is required; bulk job: object is required",
+		},
+		{
+			name: "big object",
+			options: Options{
+				Object:    "Account",
+				Operation: Upsert,
+				JobType:   BigObjects,
+			},
+			wantErr: "bulk job: external id field name is required for upsert operation; " +
+				"bulk job: big object jobs only support the insert operation; " +
+				"bulk job: Account is not a big object",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.ValidateAll()
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.wantErr {
+				t.Errorf("Options.ValidateAll() error = %v, wantErr %v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_Normalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		want    Options
+	}{
+		{
+			name: "defaults",
+			options: Options{
+				Object:    "Account",
+				Operation: Insert,
+			},
+			want: Options{
+				ColumnDelimiter: Comma,
+				ContentType:     CSV,
+				LineEnding:      Linefeed,
+				Object:          "Account",
+				Operation:       Insert,
+				JobType:         V2Ingest,
+			},
+		},
+		{
+			name: "keep set options",
+			options: Options{
+				ColumnDelimiter: Tab,
+				LineEnding:      CarriageReturnLinefeed,
+				Object:          "Account",
+				Operation:       Insert,
+			},
+			want: Options{
+				ColumnDelimiter: Tab,
+				ContentType:     CSV,
+				LineEnding:      CarriageReturnLinefeed,
+				Object:          "Account",
+				Operation:       Insert,
+				JobType:         V2Ingest,
+			},
+		},
+		{
+			name: "remove external id field for insert",
+			options: Options{
+				ExternalIDFieldName: "External_Id__c",
+				Object:              "Account",
+				Operation:           Insert,
+			},
+			want: Options{
+				ColumnDelimiter: Comma,
+				ContentType:     CSV,
+				LineEnding:      Linefeed,
+				Object:          "Account",
+				Operation:       Insert,
+				JobType:         V2Ingest,
+			},
+		},
+		{
+			name: "keep external id field for upsert",
+			options: Options{
+				ExternalIDFieldName: "External_Id__c",
+				Object:              "Account",
+				Operation:           Upsert,
+			},
+			want: Options{
+				ColumnDelimiter:     Comma,
+				ContentType:         CSV,
+				ExternalIDFieldName: "External_Id__c",
+				LineEnding:          Linefeed,
+				Object:              "Account",
+				Operation:           Upsert,
+				JobType:             V2Ingest,
+			},
+		},
+		{
+			name: "keep job type",
+			options: Options{
+				Object:    "Customer_Interaction__b",
+				Operation: Insert,
+				JobType:   BigObjects,
+			},
+			want: Options{
+				ColumnDelimiter: Comma,
+				ContentType:     CSV,
+				LineEnding:      Linefeed,
+				Object:          "Customer_Interaction__b",
+				Operation:       Insert,
+				JobType:         BigObjects,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.Normalize()
+			if !reflect.DeepEqual(tt.options, tt.want) {
+				t.Errorf("Options.Normalize() = %v, want %v", tt.options, tt.want)
+			}
+		})
+	}
+}
+
 func TestJob_delimiter(t *testing.T) {
 	type fields struct {
 		session session.ServiceFormatter
@@ -202,6 +436,62 @@ func TestJob_delimiter(t *testing.T) {
 	}
 }
 
+func TestColumnDelimiter_Rune(t *testing.T) {
+	tests := []struct {
+		name      string
+		delimiter ColumnDelimiter
+		want      rune
+	}{
+		{
+			name:      "tab",
+			delimiter: Tab,
+			want:      '\t',
+		},
+		{
+			name:      "back quote",
+			delimiter: Backquote,
+			want:      '`',
+		},
+		{
+			name:      "caret",
+			delimiter: Caret,
+			want:      '^',
+		},
+		{
+			name:      "comma",
+			delimiter: Comma,
+			want:      ',',
+		},
+		{
+			name:      "pipe",
+			delimiter: Pipe,
+			want:      '|',
+		},
+		{
+			name:      "semi colon",
+			delimiter: SemiColon,
+			want:      ';',
+		},
+		{
+			name:      "empty",
+			delimiter: "",
+			want:      ',',
+		},
+		{
+			name:      "unknown",
+			delimiter: "SPACE",
+			want:      ',',
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.delimiter.Rune(); got != tt.want {
+				t.Errorf("ColumnDelimiter.Rune() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestJob_record(t *testing.T) {
 	type fields struct {
 		session session.ServiceFormatter
@@ -252,6 +542,56 @@ func TestJob_record(t *testing.T) {
 	}
 }
 
+func TestJob_resultRecord(t *testing.T) {
+	type args struct {
+		fields []string
+		values []string
+	}
+	tests := []struct {
+		name    string
+		options ParseOptions
+		args    args
+		want    map[string]string
+	}{
+		{
+			name: "drop meta columns",
+			args: args{
+				fields: []string{"first", "sf__Id", "last", "sf__Created"},
+				values: []string{"john", "1234", "doe", "true"},
+			},
+			want: map[string]string{
+				"first": "john",
+				"last":  "doe",
+			},
+		},
+		{
+			name: "keep meta columns",
+			options: ParseOptions{
+				KeepMetaColumns: true,
+			},
+			args: args{
+				fields: []string{"sf__Id", "sf__Error", "first"},
+				values: []string{"1234", "REQUIRED_FIELD_MISSING", "john"},
+			},
+			want: map[string]string{
+				"sf__Id":    "1234",
+				"sf__Error": "REQUIRED_FIELD_MISSING",
+				"first":     "john",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				ParseOptions: tt.options,
+			}
+			if got := j.resultRecord(tt.args.fields, tt.args.values); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Job.resultRecord() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestJob_fields(t *testing.T) {
 	type fields struct {
 		session session.ServiceFormatter
@@ -500,6 +840,57 @@ func TestJob_createCallout(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Big Object",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						var body map[string]interface{}
+						if err := json.NewDecoder(req.Body).Decode(&body); err != nil || body["jobType"] != "BigObjectIngest" {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid Body",
+								Body:       ioutil.NopCloser(strings.NewReader(`[{"message":"invalid body","errorCode":"INVALID"}]`)),
+								Header:     make(http.Header),
+							}
+						}
+
+						resp := `{
+							"id": "9876",
+							"jobType": "BigObjectIngest",
+							"object": "Customer_Interaction__b",
+							"operation": "insert",
+							"state": "Open"
+						}`
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "Good",
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			args: args{
+				options: Options{
+					ColumnDelimiter: Comma,
+					ContentType:     CSV,
+					LineEnding:      Linefeed,
+					Object:          "Customer_Interaction__b",
+					Operation:       Insert,
+					JobType:         BigObjects,
+				},
+			},
+			want: WriteResponse{
+				ID:        "9876",
+				JobType:   BigObjects,
+				Object:    "Customer_Interaction__b",
+				Operation: Insert,
+				State:     Open,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -532,6 +923,7 @@ func TestJob_create(t *testing.T) {
 		fields  fields
 		args    args
 		wantErr bool
+		errMsg  string
 	}{
 		{
 			name: "Passing",
@@ -587,6 +979,32 @@ func TestJob_create(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Salesforce Error",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						resp := `[{"errorCode":"INVALIDENTITY","message":"Entity 'Acount' is not supported by the Bulk API."}]`
+						return &http.Response{
+							StatusCode: http.StatusBadRequest,
+							Status:     "400 Bad Request",
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			args: args{
+				options: Options{
+					ExternalIDFieldName: "External_Id__c",
+					Object:              "Acount",
+					Operation:           Upsert,
+				},
+			},
+			wantErr: true,
+			errMsg:  "bulk job [Acount upsert]: 400 Bad Request: INVALIDENTITY: Entity 'Acount' is not supported by the Bulk API.",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -594,9 +1012,13 @@ func TestJob_create(t *testing.T) {
 				session:       tt.fields.session,
 				WriteResponse: tt.fields.info,
 			}
-			if err := j.create(tt.args.options); (err != nil) != tt.wantErr {
+			err := j.create(tt.args.options)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("Job.create() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.errMsg != "" && err != nil && err.Error() != tt.errMsg {
+				t.Errorf("Job.create() error = %v, want %v", err, tt.errMsg)
+			}
 		})
 	}
 }
@@ -1017,6 +1439,7 @@ func TestJob_Delete(t *testing.T) {
 		name    string
 		fields  fields
 		wantErr bool
+		errMsg  string
 	}{
 		{
 			name: "Passing",
@@ -1096,6 +1519,28 @@ func TestJob_Delete(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Salesforce Error",
+			fields: fields{
+				info: WriteResponse{
+					ID: "1234",
+				},
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						resp := `[{"errorCode":"API_ERROR","message":"Job is still in progress"}]`
+						return &http.Response{
+							StatusCode: http.StatusBadRequest,
+							Status:     "400 Bad Request",
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			wantErr: true,
+			errMsg:  "400 Bad Request: API_ERROR: Job is still in progress",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1103,9 +1548,13 @@ func TestJob_Delete(t *testing.T) {
 				session:       tt.fields.session,
 				WriteResponse: tt.fields.info,
 			}
-			if err := j.Delete(); (err != nil) != tt.wantErr {
+			err := j.Delete()
+			if (err != nil) != tt.wantErr {
 				t.Errorf("Job.Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.errMsg != "" && err != nil && err.Error() != tt.errMsg {
+				t.Errorf("Job.Delete() error = %v, want %v", err, tt.errMsg)
+			}
 		})
 	}
 }
@@ -1166,16 +1615,103 @@ func TestJob_Upload(t *testing.T) {
 			},
 			wantErr: false,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			j := &Job{
-				session:       tt.fields.session,
-				WriteResponse: tt.fields.info,
-			}
-			if err := j.Upload(tt.args.body); (err != nil) != tt.wantErr {
-				t.Errorf("Job.Upload() error = %v, wantErr %v", err, tt.wantErr)
-			}
+		{
+			name: "Content URL",
+			fields: fields{
+				info: WriteResponse{
+					ID:         "1234",
+					ContentURL: "services/data/v42.0/jobs/ingest/1234/batches",
+				},
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.String() != "https://test.salesforce.com/services/data/v42.0/jobs/ingest/1234/batches" {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid URL",
+								Body:       ioutil.NopCloser(strings.NewReader(`[{"message":"invalid url","errorCode":"NOT_FOUND"}]`)),
+								Header:     make(http.Header),
+							}
+						}
+
+						return &http.Response{
+							StatusCode: http.StatusCreated,
+							Status:     "Good",
+							Body:       ioutil.NopCloser(strings.NewReader("")),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			args: args{
+				body: strings.NewReader("some reader"),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				session:       tt.fields.session,
+				WriteResponse: tt.fields.info,
+			}
+			if err := j.Upload(tt.args.body); (err != nil) != tt.wantErr {
+				t.Errorf("Job.Upload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJob_UploadLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   int64
+		body    string
+		wantErr error
+	}{
+		{
+			name:    "no limit",
+			limit:   0,
+			body:    "Name\nsome name\n",
+			wantErr: nil,
+		},
+		{
+			name:    "under limit",
+			limit:   100,
+			body:    "Name\nsome name\n",
+			wantErr: nil,
+		},
+		{
+			name:    "over limit",
+			limit:   10,
+			body:    "Name\nsome name\n",
+			wantErr: ErrUploadTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						io.Copy(ioutil.Discard, req.Body)
+						return &http.Response{
+							StatusCode: http.StatusCreated,
+							Status:     "Good",
+							Body:       ioutil.NopCloser(strings.NewReader("")),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				WriteResponse: WriteResponse{
+					ID: "1234",
+				},
+				UploadLimit: tt.limit,
+			}
+			err := j.Upload(strings.NewReader(tt.body))
+			if errors.Is(err, tt.wantErr) == false || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("Job.Upload() error = %v, wantErr %v", err, tt.wantErr)
+			}
 		})
 	}
 }
@@ -1195,9 +1731,425 @@ func TestJob_SuccessfulRecords(t *testing.T) {
 			name: "Passing",
 			fields: fields{
 				info: WriteResponse{
-					ID:              "1234",
-					ColumnDelimiter: Pipe,
-					LineEnding:      Linefeed,
+					ID:              "1234",
+					ColumnDelimiter: Pipe,
+					LineEnding:      Linefeed,
+				},
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.String() != "https://test.salesforce.com/jobs/ingest/1234/successfulResults/" {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid URL",
+								Body:       ioutil.NopCloser(strings.NewReader(req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+
+						if req.Method != http.MethodGet {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid Method",
+								Body:       ioutil.NopCloser(strings.NewReader(req.Method)),
+								Header:     make(http.Header),
+							}
+						}
+
+						resp := "sf__Created|sf__Id|FirstName|LastName|DOB\ntrue|2345|John|Doe|1/1/1970\ntrue|9876|Jane|Doe|1/1/1980\n"
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "Good",
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+
+					}),
+				},
+			},
+			want: []SuccessfulRecord{
+				{
+					Created: true,
+					JobRecord: JobRecord{
+						ID: "2345",
+						UnprocessedRecord: UnprocessedRecord{
+							Fields: map[string]string{
+								"FirstName": "John",
+								"LastName":  "Doe",
+								"DOB":       "1/1/1970",
+							},
+						},
+					},
+				},
+				{
+					Created: true,
+					JobRecord: JobRecord{
+						ID: "9876",
+						UnprocessedRecord: UnprocessedRecord{
+							Fields: map[string]string{
+								"FirstName": "Jane",
+								"LastName":  "Doe",
+								"DOB":       "1/1/1980",
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				session:       tt.fields.session,
+				WriteResponse: tt.fields.info,
+			}
+			got, err := j.SuccessfulRecords()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.SuccessfulRecords() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Job.SuccessfulRecords() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_getResults(t *testing.T) {
+	backoff := resultsReadyBackoff
+	defer func() {
+		resultsReadyBackoff = backoff
+	}()
+	resultsReadyBackoff = time.Millisecond
+
+	tests := []struct {
+		name     string
+		notFound int
+		state    State
+		timeout  time.Duration
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "ready",
+			notFound: 0,
+			state:    JobComplete,
+			timeout:  time.Second,
+			want:     "sf__Created,sf__Id\ntrue,2345\n",
+			wantErr:  false,
+		},
+		{
+			name:     "not ready",
+			notFound: 2,
+			state:    JobComplete,
+			timeout:  time.Second,
+			want:     "sf__Created,sf__Id\ntrue,2345\n",
+			wantErr:  false,
+		},
+		{
+			name:     "not complete",
+			notFound: 2,
+			state:    InProgress,
+			timeout:  time.Second,
+			wantErr:  true,
+		},
+		{
+			name:     "timeout",
+			notFound: 100,
+			state:    JobComplete,
+			timeout:  10 * time.Millisecond,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			j := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.String() == "https://test.salesforce.com/jobs/ingest/1234" {
+							return &http.Response{
+								StatusCode: http.StatusOK,
+								Status:     "Good",
+								Body:       ioutil.NopCloser(strings.NewReader(`{"id":"1234","state":"` + string(tt.state) + `"}`)),
+								Header:     make(http.Header),
+							}
+						}
+						calls++
+						if calls <= tt.notFound {
+							return &http.Response{
+								StatusCode: http.StatusNotFound,
+								Status:     "Not Found",
+								Body:       ioutil.NopCloser(strings.NewReader(`[{"message":"not found","errorCode":"NOT_FOUND"}]`)),
+								Header:     make(http.Header),
+							}
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "Good",
+							Body:       ioutil.NopCloser(strings.NewReader("sf__Created,sf__Id\ntrue,2345\n")),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				WriteResponse: WriteResponse{
+					ID: "1234",
+				},
+				ParseOptions: ParseOptions{
+					ResultsReadyTimeout: tt.timeout,
+				},
+			}
+			w := &strings.Builder{}
+			if err := j.WriteSuccessfulResults(w); (err != nil) != tt.wantErr {
+				t.Errorf("Job.WriteSuccessfulResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("Job.WriteSuccessfulResults() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_ParseOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    ParseOptions
+		wantAccept string
+		wantErr    bool
+	}{
+		{
+			name:       "defaults",
+			options:    ParseOptions{},
+			wantAccept: "text/csv",
+			wantErr:    true,
+		},
+		{
+			name: "configured",
+			options: ParseOptions{
+				Accept: "text/csv; charset=UTF-8",
+				CSVReader: func(reader *csv.Reader) {
+					reader.FieldsPerRecord = -1
+				},
+			},
+			wantAccept: "text/csv; charset=UTF-8",
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var accept string
+			j := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						accept = req.Header.Get("Accept")
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "Good",
+							Body:       ioutil.NopCloser(strings.NewReader("sf__Created,sf__Id,Name\ntrue,2345\n")),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				WriteResponse: WriteResponse{
+					ID: "1234",
+				},
+				ParseOptions: tt.options,
+			}
+			_, err := j.SuccessfulRecords()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.SuccessfulRecords() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if accept != tt.wantAccept {
+				t.Errorf("Job.SuccessfulRecords() Accept = %v, want %v", accept, tt.wantAccept)
+			}
+		})
+	}
+}
+
+func TestJob_ParseOptions_Tolerant(t *testing.T) {
+	const results = "\"sf__Id\",\"sf__Error\",Name\n" +
+		"\"\",\"INVALID_FIELD:bad \"value\":--\",Golang\n" +
+		"\"\",\"REQUIRED_FIELD_MISSING:Name:--\",Gopher,Extra\n"
+	tests := []struct {
+		name    string
+		options ParseOptions
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "defaults",
+			options: ParseOptions{},
+			wantErr: true,
+		},
+		{
+			name: "lazy_quotes",
+			options: ParseOptions{
+				LazyQuotes: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "lazy_quotes_any_fields",
+			options: ParseOptions{
+				LazyQuotes:      true,
+				FieldsPerRecord: -1,
+			},
+			want:    2,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				ParseOptions: tt.options,
+			}
+			got, err := j.ParseFailedResults(strings.NewReader(results))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.ParseFailedResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("Job.ParseFailedResults() = %v, want %d records", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_ResultsReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		reader  func(*Job) (io.ReadCloser, error)
+		status  int
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "successful",
+			reader:  (*Job).SuccessfulResultsReader,
+			status:  http.StatusOK,
+			want:    "/jobs/ingest/1234/successfulResults/",
+			wantErr: false,
+		},
+		{
+			name:    "failed",
+			reader:  (*Job).FailedResultsReader,
+			status:  http.StatusOK,
+			want:    "/jobs/ingest/1234/failedResults/",
+			wantErr: false,
+		},
+		{
+			name:    "error",
+			reader:  (*Job).FailedResultsReader,
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						body := req.URL.Path
+						if tt.status != http.StatusOK {
+							body = `[{"message":"bad request","errorCode":"INVALIDJOBSTATE"}]`
+						}
+						return &http.Response{
+							StatusCode: tt.status,
+							Status:     http.StatusText(tt.status),
+							Body:       ioutil.NopCloser(strings.NewReader(body)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				WriteResponse: WriteResponse{
+					ID: "1234",
+				},
+			}
+			results, err := tt.reader(j)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job results reader error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			defer results.Close()
+			got, err := ioutil.ReadAll(results)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Job results reader = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_resultsURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		want     string
+	}{
+		{
+			name:     "successful results",
+			resource: successfulResults,
+			want:     "https://test.salesforce.com/jobs/ingest/1234/successfulResults/",
+		},
+		{
+			name:     "failed results",
+			resource: failedResults,
+			want:     "https://test.salesforce.com/jobs/ingest/1234/failedResults/",
+		},
+		{
+			name:     "unprocessed records",
+			resource: unprocessedRecords,
+			want:     "https://test.salesforce.com/jobs/ingest/1234/unprocessedrecords/",
+		},
+		{
+			name:     "slashes",
+			resource: "/failedResults/",
+			want:     "https://test.salesforce.com/jobs/ingest/1234/failedResults/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+				},
+				WriteResponse: WriteResponse{
+					ID: "1234",
+				},
+			}
+			if got := j.resultsURL(tt.resource); got != tt.want {
+				t.Errorf("Job.resultsURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_WriteSuccessfulResults(t *testing.T) {
+	type fields struct {
+		session session.ServiceFormatter
+		info    WriteResponse
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Passing",
+			fields: fields{
+				info: WriteResponse{
+					ID: "1234",
 				},
 				session: &mockSessionFormatter{
 					url: "https://test.salesforce.com",
@@ -1211,49 +2163,198 @@ func TestJob_SuccessfulRecords(t *testing.T) {
 							}
 						}
 
-						if req.Method != http.MethodGet {
-							return &http.Response{
-								StatusCode: 500,
-								Status:     "Invalid Method",
-								Body:       ioutil.NopCloser(strings.NewReader(req.Method)),
-								Header:     make(http.Header),
-							}
-						}
-
-						resp := "sf__Created|sf__Id|FirstName|LastName|DOB\ntrue|2345|John|Doe|1/1/1970\ntrue|9876|Jane|Doe|1/1/1980\n"
+						resp := "sf__Created,sf__Id,FirstName\ntrue,2345,John\n"
 						return &http.Response{
 							StatusCode: http.StatusOK,
 							Status:     "Good",
 							Body:       ioutil.NopCloser(strings.NewReader(resp)),
 							Header:     make(http.Header),
 						}
-
 					}),
 				},
 			},
+			want:    "sf__Created,sf__Id,FirstName\ntrue,2345,John\n",
+			wantErr: false,
+		},
+		{
+			name: "Error",
+			fields: fields{
+				info: WriteResponse{
+					ID: "1234",
+				},
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						return &http.Response{
+							StatusCode: http.StatusNotFound,
+							Status:     "Not Found",
+							Body:       ioutil.NopCloser(strings.NewReader("")),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				session:       tt.fields.session,
+				WriteResponse: tt.fields.info,
+			}
+			w := &strings.Builder{}
+			if err := j.WriteSuccessfulResults(w); (err != nil) != tt.wantErr {
+				t.Errorf("Job.WriteSuccessfulResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("Job.WriteSuccessfulResults() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_ParseSuccessfulResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		stream  string
+		want    []SuccessfulRecord
+		wantErr bool
+	}{
+		{
+			name:   "meta columns last",
+			stream: "FirstName,LastName,sf__Id,sf__Created\nJohn,Doe,2345,false\n",
 			want: []SuccessfulRecord{
 				{
-					Created: true,
+					Created: false,
 					JobRecord: JobRecord{
 						ID: "2345",
 						UnprocessedRecord: UnprocessedRecord{
 							Fields: map[string]string{
 								"FirstName": "John",
 								"LastName":  "Doe",
-								"DOB":       "1/1/1970",
 							},
 						},
 					},
 				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "missing meta column",
+			stream:  "FirstName,LastName,sf__Id\nJohn,Doe,2345\n",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{}
+			got, err := j.ParseSuccessfulResults(strings.NewReader(tt.stream))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.ParseSuccessfulResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Job.ParseSuccessfulResults() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_ReadSuccessfulResults(t *testing.T) {
+	const results = "sf__Created,sf__Id,Name\ntrue,2345,Acme\n"
+	want := []SuccessfulRecord{
+		{
+			Created: true,
+			JobRecord: JobRecord{
+				ID: "2345",
+				UnprocessedRecord: UnprocessedRecord{
+					Fields: map[string]string{
+						"Name": "Acme",
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		content func() []byte
+		want    []SuccessfulRecord
+		wantErr bool
+	}{
+		{
+			name: "plain",
+			content: func() []byte {
+				return []byte(results)
+			},
+			want:    want,
+			wantErr: false,
+		},
+		{
+			name: "gzip",
+			content: func() []byte {
+				var buffer bytes.Buffer
+				writer := gzip.NewWriter(&buffer)
+				writer.Write([]byte(results))
+				writer.Close()
+				return buffer.Bytes()
+			},
+			want:    want,
+			wantErr: false,
+		},
+		{
+			name: "corrupt gzip",
+			content: func() []byte {
+				return []byte{0x1f, 0x8b, 0x00}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ioutil.TempFile("", "results")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(f.Name())
+			f.Write(tt.content())
+			f.Close()
+
+			j := &Job{}
+			got, err := j.ReadSuccessfulResults(f.Name())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.ReadSuccessfulResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Job.ReadSuccessfulResults() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_ParseFailedResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		stream  string
+		want    []FailedRecord
+		wantErr bool
+	}{
+		{
+			name:   "meta columns reordered",
+			stream: "sf__Error,FirstName,sf__Id\nREQUIRED_FIELD_MISSING,John,\n",
+			want: []FailedRecord{
 				{
-					Created: true,
+					Error: "REQUIRED_FIELD_MISSING",
 					JobRecord: JobRecord{
-						ID: "9876",
+						ID: "",
 						UnprocessedRecord: UnprocessedRecord{
 							Fields: map[string]string{
-								"FirstName": "Jane",
-								"LastName":  "Doe",
-								"DOB":       "1/1/1980",
+								"FirstName": "John",
 							},
 						},
 					},
@@ -1261,20 +2362,23 @@ func TestJob_SuccessfulRecords(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "missing meta column",
+			stream:  "FirstName,sf__Id\nJohn,2345\n",
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			j := &Job{
-				session:       tt.fields.session,
-				WriteResponse: tt.fields.info,
-			}
-			got, err := j.SuccessfulRecords()
+			j := &Job{}
+			got, err := j.ParseFailedResults(strings.NewReader(tt.stream))
 			if (err != nil) != tt.wantErr {
-				t.Errorf("Job.SuccessfulRecords() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("Job.ParseFailedResults() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("Job.SuccessfulRecords() = %v, want %v", got, tt.want)
+				t.Errorf("Job.ParseFailedResults() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -1465,3 +2569,315 @@ func TestJob_UnprocessedRecords(t *testing.T) {
 		})
 	}
 }
+
+func TestInfo_JSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    Info
+	}{
+		{
+			name: "create job response",
+			payload: `{
+				"id": "7505fEXAMPLE4C2AAM",
+				"operation": "insert",
+				"object": "Account",
+				"createdById": "0055fEXAMPLEtG4AAM",
+				"createdDate": "2022-01-02T21:33:43.000+0000",
+				"systemModstamp": "2022-01-02T21:33:43.000+0000",
+				"state": "Open",
+				"concurrencyMode": "Parallel",
+				"contentType": "CSV",
+				"apiVersion": 53.0,
+				"contentUrl": "services/data/53.0/jobs/ingest/7505fEXAMPLE4C2AAM/batches",
+				"lineEnding": "LF",
+				"columnDelimiter": "COMMA"
+			}`,
+			want: Info{
+				WriteResponse: WriteResponse{
+					APIVersion:      53,
+					ColumnDelimiter: Comma,
+					ConcurrencyMode: "Parallel",
+					ContentType:     "CSV",
+					ContentURL:      "services/data/53.0/jobs/ingest/7505fEXAMPLE4C2AAM/batches",
+					CreatedByID:     "0055fEXAMPLEtG4AAM",
+					CreatedDate:     "2022-01-02T21:33:43.000+0000",
+					ID:              "7505fEXAMPLE4C2AAM",
+					LineEnding:      Linefeed,
+					Object:          "Account",
+					Operation:       Insert,
+					State:           Open,
+					SystemModstamp:  "2022-01-02T21:33:43.000+0000",
+				},
+			},
+		},
+		{
+			name: "job info response",
+			payload: `{
+				"id": "7505fEXAMPLE4C2AAM",
+				"operation": "insert",
+				"object": "Account",
+				"createdById": "0055fEXAMPLEtG4AAM",
+				"createdDate": "2022-01-02T21:33:43.000+0000",
+				"systemModstamp": "2022-01-02T21:38:31.000+0000",
+				"state": "JobComplete",
+				"concurrencyMode": "Parallel",
+				"contentType": "CSV",
+				"apiVersion": 53.0,
+				"jobType": "V2Ingest",
+				"lineEnding": "LF",
+				"columnDelimiter": "COMMA",
+				"numberRecordsProcessed": 2,
+				"numberRecordsFailed": 1,
+				"retries": 0,
+				"totalProcessingTime": 886,
+				"apiActiveProcessingTime": 813,
+				"apexProcessingTime": 0
+			}`,
+			want: Info{
+				WriteResponse: WriteResponse{
+					APIVersion:      53,
+					ColumnDelimiter: Comma,
+					ConcurrencyMode: "Parallel",
+					ContentType:     "CSV",
+					CreatedByID:     "0055fEXAMPLEtG4AAM",
+					CreatedDate:     "2022-01-02T21:33:43.000+0000",
+					ID:              "7505fEXAMPLE4C2AAM",
+					JobType:         V2Ingest,
+					LineEnding:      Linefeed,
+					Object:          "Account",
+					Operation:       Insert,
+					State:           JobComplete,
+					SystemModstamp:  "2022-01-02T21:38:31.000+0000",
+				},
+				APIActiveProcessingTime: 813,
+				NumberRecordsFailed:     1,
+				NumberRecordsProcessed:  2,
+				TotalProcessingTime:     886,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Info
+			if err := json.Unmarshal([]byte(tt.payload), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("json.Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+
+			data, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if strings.Contains(string(data), `"apiVersion":53.0`) == false {
+				t.Errorf("json.Marshal() = %s, want apiVersion 53.0", data)
+			}
+			var roundTrip Info
+			if err := json.Unmarshal(data, &roundTrip); err != nil {
+				t.Fatalf("json.Unmarshal() round trip error = %v", err)
+			}
+			if !reflect.DeepEqual(roundTrip, tt.want) {
+				t.Errorf("json.Unmarshal() round trip = %+v, want %+v", roundTrip, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_CanUpload(t *testing.T) {
+	tests := []struct {
+		name  string
+		state State
+		want  bool
+	}{
+		{
+			name:  "open",
+			state: Open,
+			want:  true,
+		},
+		{
+			name:  "upload complete",
+			state: UpdateComplete,
+			want:  false,
+		},
+		{
+			name:  "aborted",
+			state: Aborted,
+			want:  false,
+		},
+		{
+			name:  "unknown",
+			state: "",
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				WriteResponse: WriteResponse{
+					State: tt.state,
+				},
+			}
+			if got := j.CanUpload(); got != tt.want {
+				t.Errorf("Job.CanUpload() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_CanUpload_TrackedState(t *testing.T) {
+	state := "Open"
+	j := &Job{
+		session: &mockSessionFormatter{
+			url: "https://test.salesforce.com",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				if req.Method == http.MethodPatch {
+					state = "UploadComplete"
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Status:     "Good",
+					Body:       ioutil.NopCloser(strings.NewReader(`{"id":"1234","state":"` + state + `"}`)),
+					Header:     make(http.Header),
+				}
+			}),
+		},
+		WriteResponse: WriteResponse{
+			ID: "1234",
+		},
+	}
+
+	if _, err := j.Info(); err != nil {
+		t.Fatalf("Job.Info() error = %v", err)
+	}
+	if j.CanUpload() == false {
+		t.Errorf("Job.CanUpload() = false after the open job's info")
+	}
+	if _, err := j.Close(); err != nil {
+		t.Fatalf("Job.Close() error = %v", err)
+	}
+	if j.CanUpload() {
+		t.Errorf("Job.CanUpload() = true after the job was closed")
+	}
+}
+
+func TestJob_ExportFailedResults(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		want     string
+		wantFile bool
+		wantErr  bool
+	}{
+		{
+			name:     "exported",
+			status:   http.StatusOK,
+			want:     "sf__Id,sf__Error,Name\n,REQUIRED_FIELD_MISSING,\n",
+			wantFile: true,
+			wantErr:  false,
+		},
+		{
+			name:     "error response",
+			status:   http.StatusBadRequest,
+			wantFile: false,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "results")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			filename := filepath.Join(dir, "failed.csv")
+
+			j := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.String() != "https://test.salesforce.com/jobs/ingest/1234/failedResults/" || tt.status != http.StatusOK {
+							return &http.Response{
+								StatusCode: http.StatusBadRequest,
+								Status:     "Bad Request",
+								Body:       ioutil.NopCloser(strings.NewReader(`[{"message":"bad request","errorCode":"INVALIDJOB"}]`)),
+								Header:     make(http.Header),
+							}
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "Good",
+							Body:       ioutil.NopCloser(strings.NewReader("sf__Id,sf__Error,Name\n,REQUIRED_FIELD_MISSING,\n")),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				WriteResponse: WriteResponse{
+					ID: "1234",
+				},
+			}
+
+			err = j.ExportFailedResults(filename)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.ExportFailedResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			got, readErr := ioutil.ReadFile(filename)
+			if (readErr == nil) != tt.wantFile {
+				t.Errorf("Job.ExportFailedResults() file error = %v, wantFile %v", readErr, tt.wantFile)
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("Job.ExportFailedResults() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_recordAttributes(t *testing.T) {
+	var attributes []map[string]string
+	j := &Job{
+		session: &mockSessionFormatter{
+			url: "https://test.salesforce.com",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				traced := sfdc.TraceAttributes(req)
+				attributes = append(attributes, map[string]string{
+					sfdc.TraceRecordsProcessed: traced[sfdc.TraceRecordsProcessed],
+					sfdc.TraceRecordsFailed:    traced[sfdc.TraceRecordsFailed],
+				})
+				if req.URL.String() == "https://test.salesforce.com/jobs/ingest/1234" {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     "Good",
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id":"1234","state":"JobComplete","numberRecordsProcessed":10,"numberRecordsFailed":2}`)),
+						Header:     make(http.Header),
+					}
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Status:     "Good",
+					Body:       ioutil.NopCloser(strings.NewReader("sf__Created,sf__Id\ntrue,2345\n")),
+					Header:     make(http.Header),
+				}
+			}),
+		},
+		WriteResponse: WriteResponse{
+			ID: "1234",
+		},
+	}
+
+	if _, err := j.Info(); err != nil {
+		t.Fatalf("Job.Info() error = %v", err)
+	}
+	if err := j.WriteSuccessfulResults(ioutil.Discard); err != nil {
+		t.Fatalf("Job.WriteSuccessfulResults() error = %v", err)
+	}
+	want := []map[string]string{
+		{sfdc.TraceRecordsProcessed: "", sfdc.TraceRecordsFailed: ""},
+		{sfdc.TraceRecordsProcessed: "10", sfdc.TraceRecordsFailed: "2"},
+	}
+	if reflect.DeepEqual(attributes, want) == false {
+		t.Errorf("Job trace attributes = %v, want %v", attributes, want)
+	}
+}
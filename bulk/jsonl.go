@@ -0,0 +1,54 @@
+package bulk
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// SuccessfulResultsJSONL streams the successful results to the writer as JSON
+// lines, one JSON object per results row.  The object has the columns of the
+// results header, including sf__Created and sf__Id, with the values as strings.
+// The rows are parsed with the column delimiter and parse options of the job and
+// written as they are read, so the results are not held in memory.
+func (j *Job) SuccessfulResultsJSONL(w io.Writer) error {
+	if w == nil {
+		return errors.New("bulk job: writer can not be nil")
+	}
+
+	results, err := j.SuccessfulResultsReader()
+	if err != nil {
+		return err
+	}
+	defer results.Close()
+
+	return j.writeJSONL(w, results)
+}
+
+func (j *Job) writeJSONL(w io.Writer, results io.Reader) error {
+	reader := j.csvReader(results)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fields := j.fields(header, 0)
+
+	encoder := json.NewEncoder(w)
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := encoder.Encode(j.record(fields, values)); err != nil {
+			return err
+		}
+	}
+}
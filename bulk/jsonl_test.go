@@ -0,0 +1,84 @@
+package bulk
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestJob_SuccessfulResultsJSONL(t *testing.T) {
+	tests := []struct {
+		name    string
+		results string
+		status  int
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Passing",
+			results: "sf__Created|sf__Id|Name|Site\ntrue|2345|Golang|\"Go|Site\"\nfalse|9876|Gopher|\n",
+			status:  http.StatusOK,
+			want: `{"Name":"Golang","Site":"Go|Site","sf__Created":"true","sf__Id":"2345"}` + "\n" +
+				`{"Name":"Gopher","Site":"","sf__Created":"false","sf__Id":"9876"}` + "\n",
+			wantErr: false,
+		},
+		{
+			name:    "No Results",
+			results: "",
+			status:  http.StatusOK,
+			want:    "",
+			wantErr: false,
+		},
+		{
+			name:    "Bad Row",
+			results: "sf__Created|sf__Id|Name\ntrue|2345|Golang|Extra\n",
+			status:  http.StatusOK,
+			wantErr: true,
+		},
+		{
+			name:    "Error Response",
+			results: `[{"errorCode":"INVALIDJOBSTATE","message":"job is not complete"}]`,
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.String() != "https://test.salesforce.com/jobs/ingest/1234/successfulResults/" {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid URL",
+								Body:       ioutil.NopCloser(strings.NewReader(req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+						return &http.Response{
+							StatusCode: tt.status,
+							Status:     http.StatusText(tt.status),
+							Body:       ioutil.NopCloser(strings.NewReader(tt.results)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				WriteResponse: WriteResponse{
+					ID:              "1234",
+					ColumnDelimiter: Pipe,
+				},
+			}
+			sb := &strings.Builder{}
+			err := job.SuccessfulResultsJSONL(sb)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.SuccessfulResultsJSONL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == false && sb.String() != tt.want {
+				t.Errorf("Job.SuccessfulResultsJSONL() = %q, want %q", sb.String(), tt.want)
+			}
+		})
+	}
+}
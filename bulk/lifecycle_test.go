@@ -0,0 +1,295 @@
+package bulk_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enrique-esquivel/go-sfdc/bulk"
+	"github.com/enrique-esquivel/go-sfdc/session/sessiontest"
+)
+
+const fakeIngestPath = "/services/data/v45.0/jobs/ingest"
+
+// fakeBulkServer implements the bulk 2.0 ingest endpoints.  The job data is
+// processed when the job is closed, and records without a Name fail.
+type fakeBulkServer struct {
+	mu   sync.Mutex
+	jobs map[string]*fakeBulkJob
+}
+
+type fakeBulkJob struct {
+	info      bulk.Info
+	data      []byte
+	succeeded [][]string
+	failed    [][]string
+	header    []string
+}
+
+func newFakeBulkServer() *fakeBulkServer {
+	return &fakeBulkServer{
+		jobs: make(map[string]*fakeBulkJob),
+	}
+}
+
+func (s *fakeBulkServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, fakeIngestPath), "/")
+	parts := strings.Split(path, "/")
+	if path == "" {
+		if r.Method != http.MethodPost {
+			s.error(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED")
+			return
+		}
+		s.create(w, r)
+		return
+	}
+
+	job, ok := s.jobs[parts[0]]
+	if ok == false {
+		s.error(w, http.StatusNotFound, "NOT_FOUND")
+		return
+	}
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		s.json(w, http.StatusOK, job.info)
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		s.setState(w, r, job)
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		delete(s.jobs, parts[0])
+		w.WriteHeader(http.StatusNoContent)
+	case len(parts) == 2 && parts[1] == "batches" && r.Method == http.MethodPut:
+		if job.info.State != bulk.Open {
+			s.error(w, http.StatusConflict, "INVALIDJOBSTATE")
+			return
+		}
+		job.data, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	case len(parts) == 2 && parts[1] == "successfulResults" && r.Method == http.MethodGet:
+		s.results(w, job, []string{"sf__Id", "sf__Created"}, job.succeeded)
+	case len(parts) == 2 && parts[1] == "failedResults" && r.Method == http.MethodGet:
+		s.results(w, job, []string{"sf__Id", "sf__Error"}, job.failed)
+	default:
+		s.error(w, http.StatusNotFound, "NOT_FOUND")
+	}
+}
+
+func (s *fakeBulkServer) create(w http.ResponseWriter, r *http.Request) {
+	var options bulk.Options
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		s.error(w, http.StatusBadRequest, "JSON_PARSER_ERROR")
+		return
+	}
+	id := fmt.Sprintf("750R0000000%04d", len(s.jobs)+1)
+	job := &fakeBulkJob{
+		info: bulk.Info{
+			WriteResponse: bulk.WriteResponse{
+				ColumnDelimiter: options.ColumnDelimiter,
+				ContentType:     string(options.ContentType),
+				ContentURL:      strings.TrimPrefix(fakeIngestPath, "/") + "/" + id + "/batches",
+				ID:              id,
+				JobType:         options.JobType,
+				LineEnding:      options.LineEnding,
+				Object:          options.Object,
+				Operation:       options.Operation,
+				State:           bulk.Open,
+			},
+		},
+	}
+	s.jobs[id] = job
+	s.json(w, http.StatusOK, job.info.WriteResponse)
+}
+
+func (s *fakeBulkServer) setState(w http.ResponseWriter, r *http.Request, job *fakeBulkJob) {
+	var state struct {
+		State bulk.State `json:"state"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&state); err != nil {
+		s.error(w, http.StatusBadRequest, "JSON_PARSER_ERROR")
+		return
+	}
+	job.info.State = state.State
+	if state.State == bulk.UpdateComplete {
+		if err := s.process(job); err != nil {
+			job.info.State = bulk.Failed
+			job.info.ErrorMessage = err.Error()
+		} else {
+			job.info.State = bulk.JobComplete
+		}
+	}
+	s.json(w, http.StatusOK, job.info.WriteResponse)
+}
+
+func (s *fakeBulkServer) process(job *fakeBulkJob) error {
+	reader := csv.NewReader(bytes.NewReader(job.data))
+	reader.Comma = job.info.ColumnDelimiter.Rune()
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("no data uploaded")
+	}
+	job.header = rows[0]
+	nameIdx := -1
+	for idx, column := range job.header {
+		if column == "Name" {
+			nameIdx = idx
+		}
+	}
+	for idx, row := range rows[1:] {
+		if nameIdx == -1 || row[nameIdx] == "" {
+			job.failed = append(job.failed, append([]string{"", "REQUIRED_FIELD_MISSING:Required fields are missing: [Name]"}, row...))
+			continue
+		}
+		id := fmt.Sprintf("001R0000000%04d", idx+1)
+		job.succeeded = append(job.succeeded, append([]string{id, "true"}, row...))
+	}
+	job.info.NumberRecordsProcessed = len(rows) - 1
+	job.info.NumberRecordsFailed = len(job.failed)
+	return nil
+}
+
+func (s *fakeBulkServer) results(w http.ResponseWriter, job *fakeBulkJob, meta []string, rows [][]string) {
+	w.Header().Set("Content-Type", "text/csv")
+	writer := csv.NewWriter(w)
+	writer.Comma = job.info.ColumnDelimiter.Rune()
+	writer.Write(append(meta, job.header...))
+	writer.WriteAll(rows)
+}
+
+func (s *fakeBulkServer) json(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func (s *fakeBulkServer) error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `[{"errorCode":%q,"message":"fake bulk server error"}]`, code)
+}
+
+func TestJob_Lifecycle(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       bulk.Options
+		data          string
+		wantState     bulk.State
+		wantSucceeded []string
+		wantFailed    []string
+		wantErr       bool
+	}{
+		{
+			name: "insert",
+			options: bulk.Options{
+				Object:    "Account",
+				Operation: bulk.Insert,
+			},
+			data:          "Name,Site\nAcme,HQ\n,Branch\nGlobex,\n",
+			wantState:     bulk.JobComplete,
+			wantSucceeded: []string{"Acme", "Globex"},
+			wantFailed:    []string{""},
+			wantErr:       false,
+		},
+		{
+			name: "pipe delimiter",
+			options: bulk.Options{
+				Object:          "Account",
+				Operation:       bulk.Insert,
+				ColumnDelimiter: bulk.Pipe,
+			},
+			data:          "Name|Site\nAcme, Inc|HQ\n",
+			wantState:     bulk.JobComplete,
+			wantSucceeded: []string{"Acme, Inc"},
+			wantErr:       false,
+		},
+		{
+			name: "no data",
+			options: bulk.Options{
+				Object:    "Account",
+				Operation: bulk.Insert,
+			},
+			data:      "",
+			wantState: bulk.Failed,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := sessiontest.NewSession(newFakeBulkServer())
+			defer fake.Close()
+
+			resource, err := bulk.NewResource(fake)
+			if err != nil {
+				t.Fatalf("bulk.NewResource() error = %v", err)
+			}
+			job, err := resource.CreateJob(tt.options)
+			if err != nil {
+				t.Fatalf("Resource.CreateJob() error = %v", err)
+			}
+			if err := job.Upload(strings.NewReader(tt.data)); err != nil {
+				t.Fatalf("Job.Upload() error = %v", err)
+			}
+			if _, err := job.Close(); err != nil {
+				t.Fatalf("Job.Close() error = %v", err)
+			}
+			info, err := job.WaitForComplete(context.Background(), time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Job.WaitForComplete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if info.State != tt.wantState {
+				t.Fatalf("Job.WaitForComplete() state = %v, want %v", info.State, tt.wantState)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			succeeded, err := job.SuccessfulRecords()
+			if err != nil {
+				t.Fatalf("Job.SuccessfulRecords() error = %v", err)
+			}
+			if got := recordNames(succeeded, nil); !reflect.DeepEqual(got, tt.wantSucceeded) {
+				t.Errorf("Job.SuccessfulRecords() = %v, want %v", got, tt.wantSucceeded)
+			}
+			failed, err := job.FailedRecords()
+			if err != nil {
+				t.Fatalf("Job.FailedRecords() error = %v", err)
+			}
+			if got := recordNames(nil, failed); !reflect.DeepEqual(got, tt.wantFailed) {
+				t.Errorf("Job.FailedRecords() = %v, want %v", got, tt.wantFailed)
+			}
+
+			if err := job.Delete(); err != nil {
+				t.Fatalf("Job.Delete() error = %v", err)
+			}
+			if _, err := job.Info(); err == nil {
+				t.Errorf("Job.Info() of a deleted job error = nil, want error")
+			}
+		})
+	}
+}
+
+func recordNames(succeeded []bulk.SuccessfulRecord, failed []bulk.FailedRecord) []string {
+	var names []string
+	for _, record := range succeeded {
+		names = append(names, record.Fields["Name"])
+	}
+	for _, record := range failed {
+		names = append(names, record.Fields["Name"])
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,103 @@
+package bulk
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/enrique-esquivel/go-sfdc/internal/csvstruct"
+)
+
+// timeFormat is the format of time values in the job data.
+const timeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// Marshal returns the CSV job data of the records, which is a slice of structs or
+// struct pointers, formatted with the column delimiter and line ending of the options.
+//
+// The column of a struct field is the name in the csv tag, or the field name when
+// there is no tag.  A field with the tag "-" is skipped.  The fields of a nested struct
+// are columns prefixed with the nested struct's column and a dot, which can be used
+// to reference a parent record by its external ID, like Account.ExternalId__c for the
+// ExternalId__c field of the Account relationship.
+//
+//	type Contact struct {
+//		LastName string `csv:"LastName"`
+//		Account  struct {
+//			ExternalID string `csv:"ExternalId__c"`
+//		} `csv:"Account"`
+//	}
+//
+// Time values are formatted as ISO 8601 date times.  Nil pointers are empty, which
+// leaves the field unchanged on update.  A string field can be set to FieldNull to
+// blank the field, or the tag can have the null option, like `csv:"Email,null"`,
+// to blank the field when its value is empty.
+func Marshal(records interface{}, options Options) ([]byte, error) {
+	value := reflect.ValueOf(records)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, errors.New("bulk marshal: records must be a slice of structs")
+	}
+	recordType := value.Type().Elem()
+	if recordType.Kind() == reflect.Ptr {
+		recordType = recordType.Elem()
+	}
+	if recordType.Kind() != reflect.Struct {
+		return nil, errors.New("bulk marshal: records must be a slice of structs")
+	}
+
+	columns, err := csvstruct.Columns(recordType)
+	if err != nil {
+		return nil, fmt.Errorf("bulk marshal: %w", err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("bulk marshal: %s does not have any columns", recordType)
+	}
+	header := make([]string, len(columns))
+	for idx, column := range columns {
+		header[idx] = column.Name
+	}
+
+	var buffer bytes.Buffer
+	writer := NewCSVWriter(&buffer, options)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	for idx := 0; idx < value.Len(); idx++ {
+		record := value.Index(idx)
+		if record.Kind() == reflect.Ptr && record.IsNil() {
+			return nil, fmt.Errorf("bulk marshal: record %d is nil", idx)
+		}
+		values := make([]string, len(columns))
+		for col, column := range columns {
+			values[col] = formatValue(column.Value(record))
+			if values[col] == "" && column.Null {
+				values[col] = FieldNull
+			}
+		}
+		if err := writer.Write(values); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func formatValue(value reflect.Value) string {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if value.IsValid() == false {
+		return ""
+	}
+	if value.Type() == csvstruct.TimeType {
+		return value.Interface().(time.Time).UTC().Format(timeFormat)
+	}
+	return fmt.Sprintf("%v", value.Interface())
+}
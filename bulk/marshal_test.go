@@ -0,0 +1,159 @@
+package bulk
+
+import (
+	"testing"
+	"time"
+)
+
+type marshalAccount struct {
+	ExternalID string `csv:"ExternalId__c"`
+}
+
+type marshalContact struct {
+	LastName  string         `csv:"LastName"`
+	Email     *string        `csv:"Email"`
+	Account   marshalAccount `csv:"Account"`
+	Owner     *marshalAccount
+	Birthdate time.Time `csv:"Birthdate"`
+	Age       int
+	Ignored   string `csv:"-"`
+	internal  string
+}
+
+type marshalParent struct {
+	Name   string `csv:"Name"`
+	Parent *marshalParent
+}
+
+type marshalChild struct {
+	Name    string `csv:"Name"`
+	Account struct {
+		Owner struct {
+			Child *marshalChild
+		}
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	email := "jdoe@example.com"
+	birthdate := time.Date(1980, time.March, 4, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		records interface{}
+		options Options
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "relationship external ids",
+			records: []marshalContact{
+				{
+					LastName: "Doe",
+					Email:    &email,
+					Account: marshalAccount{
+						ExternalID: "ACME-1",
+					},
+					Owner: &marshalAccount{
+						ExternalID: "USER-1",
+					},
+					Birthdate: birthdate,
+					Age:       40,
+					Ignored:   "ignored",
+					internal:  "internal",
+				},
+				{
+					LastName: "Smith, Jr",
+				},
+			},
+			want: "LastName,Email,Account.ExternalId__c,Owner.ExternalId__c,Birthdate,Age\n" +
+				"Doe,jdoe@example.com,ACME-1,USER-1,1980-03-04T00:00:00.000Z,40\n" +
+				"\"Smith, Jr\",,,,0001-01-01T00:00:00.000Z,0\n",
+			wantErr: false,
+		},
+		{
+			name: "embedded quotes and newlines",
+			records: []marshalAccount{
+				{
+					ExternalID: "ACME \"1\"",
+				},
+				{
+					ExternalID: "ACME\n2",
+				},
+			},
+			want:    "ExternalId__c\n\"ACME \"\"1\"\"\"\n\"ACME\n2\"\n",
+			wantErr: false,
+		},
+		{
+			name: "pointers and delimiter",
+			records: []*marshalAccount{
+				{
+					ExternalID: "ACME-1",
+				},
+			},
+			options: Options{
+				ColumnDelimiter: Pipe,
+				LineEnding:      CarriageReturnLinefeed,
+			},
+			want:    "ExternalId__c\r\nACME-1\r\n",
+			wantErr: false,
+		},
+		{
+			name: "null fields",
+			records: []struct {
+				Name  string  `csv:"Name"`
+				Phone string  `csv:"Phone"`
+				Email *string `csv:"Email,null"`
+				Site  string  `csv:",null"`
+			}{
+				{
+					Name:  "Acme",
+					Phone: FieldNull,
+				},
+			},
+			want:    "Name,Phone,Email,Site\nAcme,#N/A,#N/A,#N/A\n",
+			wantErr: false,
+		},
+		{
+			name:    "nil record",
+			records: []*marshalAccount{nil},
+			wantErr: true,
+		},
+		{
+			name:    "not a slice",
+			records: marshalAccount{},
+			wantErr: true,
+		},
+		{
+			name:    "not structs",
+			records: []string{"a"},
+			wantErr: true,
+		},
+		{
+			name:    "self reference",
+			records: []marshalParent{{Name: "Acme"}},
+			wantErr: true,
+		},
+		{
+			name:    "indirect self reference",
+			records: []*marshalChild{{Name: "Doe"}},
+			wantErr: true,
+		},
+		{
+			name:    "no columns",
+			records: []struct{ internal string }{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.records, tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Marshal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseError is a row of the job results that could not be parsed.  Row is the
+// number of the row after the header, and Line is the line of the results where
+// the row starts, when the row could not be read as CSV.
+type ParseError struct {
+	Row  int
+	Line int
+	Err  error
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors are the rows of the job results that could not be parsed, which
+// are returned along with the parsed records when the parse options collect
+// the errors.
+type ParseErrors []ParseError
+
+func (e ParseErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+
+	return fmt.Sprintf("bulk job: %d results rows could not be parsed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// Err returns the parse errors as an error, or nil if there are none.
+func (e ParseErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// rowError adds the error of the row to the parse errors when the parse options
+// collect the errors, and returns nil so the parse continues.  Otherwise the error
+// is returned to stop the parse.
+func (j *Job) rowError(errs *ParseErrors, row int, err error) error {
+	if j.ParseOptions.CollectErrors == false {
+		return err
+	}
+	parseErr := ParseError{
+		Row: row,
+		Err: err,
+	}
+	var csvErr *csv.ParseError
+	if errors.As(err, &csvErr) {
+		parseErr.Line = csvErr.StartLine
+	}
+	*errs = append(*errs, parseErr)
+	return nil
+}
+
+// readError is rowError for the error of reading a row, which only continues when
+// the row is malformed CSV, since the rest of the results can not be read after
+// other errors.
+func (j *Job) readError(errs *ParseErrors, row int, err error) error {
+	var csvErr *csv.ParseError
+	if errors.As(err, &csvErr) == false {
+		return err
+	}
+	return j.rowError(errs, row, err)
+}
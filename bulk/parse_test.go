@@ -0,0 +1,112 @@
+package bulk
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJob_ParseSuccessfulResults_CollectErrors(t *testing.T) {
+	const results = "sf__Created,sf__Id,Name\n" +
+		"true,001xx000003DGb1,Golang\n" +
+		"maybe,001xx000003DGb2,Gopher\n" +
+		"false,001xx000003DGb3,\"Go\"pher\"\n" +
+		"false,001xx000003DGb4,Go\n"
+	tests := []struct {
+		name     string
+		options  ParseOptions
+		wantIDs  []string
+		wantErrs []ParseError
+		wantErr  bool
+	}{
+		{
+			name:    "abort",
+			options: ParseOptions{},
+			wantErr: true,
+		},
+		{
+			name: "collect",
+			options: ParseOptions{
+				CollectErrors: true,
+			},
+			wantIDs: []string{"001xx000003DGb1", "001xx000003DGb4"},
+			wantErrs: []ParseError{
+				{Row: 2, Line: 0},
+				{Row: 3, Line: 4},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				ParseOptions: tt.options,
+			}
+			got, err := j.ParseSuccessfulResults(strings.NewReader(results))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.ParseSuccessfulResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var ids []string
+			for _, record := range got {
+				ids = append(ids, record.ID)
+			}
+			if reflect.DeepEqual(ids, tt.wantIDs) == false {
+				t.Errorf("Job.ParseSuccessfulResults() = %v, want %v", ids, tt.wantIDs)
+			}
+			if tt.wantErrs == nil {
+				return
+			}
+			var parseErrs ParseErrors
+			if errors.As(err, &parseErrs) == false {
+				t.Fatalf("Job.ParseSuccessfulResults() error = %v, want ParseErrors", err)
+			}
+			if len(parseErrs) != len(tt.wantErrs) {
+				t.Fatalf("Job.ParseSuccessfulResults() errors = %v, want %v", parseErrs, tt.wantErrs)
+			}
+			for idx, parseErr := range parseErrs {
+				if parseErr.Row != tt.wantErrs[idx].Row || parseErr.Line != tt.wantErrs[idx].Line {
+					t.Errorf("Job.ParseSuccessfulResults() error %d = %+v, want %+v", idx, parseErr, tt.wantErrs[idx])
+				}
+			}
+		})
+	}
+}
+
+func TestJob_ParseFailedResults_CollectErrors(t *testing.T) {
+	const results = "sf__Id,sf__Error,Name\n" +
+		",REQUIRED_FIELD_MISSING:Name:--,\n" +
+		",INVALID_FIELD:bad:--,Golang,Extra\n"
+	j := &Job{
+		ParseOptions: ParseOptions{
+			CollectErrors: true,
+		},
+	}
+	got, err := j.ParseFailedResults(strings.NewReader(results))
+	if len(got) != 1 || got[0].Error != "REQUIRED_FIELD_MISSING:Name:--" {
+		t.Errorf("Job.ParseFailedResults() = %v", got)
+	}
+	var parseErrs ParseErrors
+	if errors.As(err, &parseErrs) == false || len(parseErrs) != 1 {
+		t.Fatalf("Job.ParseFailedResults() error = %v, want one ParseError", err)
+	}
+	if parseErrs[0].Row != 2 || parseErrs[0].Line != 3 {
+		t.Errorf("Job.ParseFailedResults() error = %+v", parseErrs[0])
+	}
+}
+
+func TestJob_ParseFailedResults_CollectErrorsNone(t *testing.T) {
+	j := &Job{
+		ParseOptions: ParseOptions{
+			CollectErrors: true,
+		},
+	}
+	got, err := j.ParseFailedResults(strings.NewReader("sf__Id,sf__Error,Name\n,REQUIRED_FIELD_MISSING:Name:--,\n"))
+	if err != nil {
+		t.Errorf("Job.ParseFailedResults() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Errorf("Job.ParseFailedResults() = %v", got)
+	}
+}
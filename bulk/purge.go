@@ -0,0 +1,80 @@
+package bulk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/enrique-esquivel/go-sfdc/internal/pagination"
+)
+
+// PurgeJobs will delete the bulk 2.0 jobs that were last modified before olderThan
+// and are in one of the states, and returns the number of jobs that were deleted.
+// If no states are given, the jobs that will not be processed any further, which
+// are JobComplete, Failed and Aborted, are deleted.  The bulk 1.0 jobs are listed
+// with the bulk 2.0 jobs, but can not be deleted, so they are skipped.
+//
+// The jobs of each page are deleted before the next page is listed.  If a job can
+// not be deleted, or the pages loop, the jobs that are left are not deleted and the
+// number that were deleted is returned with the error.
+func (r *Resource) PurgeJobs(olderThan time.Time, states ...State) (int, error) {
+	if len(states) == 0 {
+		states = []State{JobComplete, Failed, Aborted}
+	}
+	purge := make(map[State]bool, len(states))
+	for _, state := range states {
+		purge[state] = true
+	}
+	jobs, err := r.AllJobs(Parameters{})
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	guard := pagination.Guard{MaxPages: r.MaxPages}
+	for {
+		for _, record := range jobs.Records() {
+			if record.JobType == Classic || purge[record.State] == false {
+				continue
+			}
+			modified, err := record.lastModified()
+			if err != nil {
+				return deleted, err
+			}
+			if modified.Before(olderThan) == false {
+				continue
+			}
+			job := &Job{
+				session:       r.session,
+				WriteResponse: record,
+			}
+			if err := job.Delete(); err != nil {
+				return deleted, fmt.Errorf("bulk job: delete job %s: %w", record.ID, err)
+			}
+			deleted++
+		}
+		if jobs.Done() {
+			break
+		}
+		if err := guard.Follow(jobs.response.NextRecordsURL); err != nil {
+			return deleted, fmt.Errorf("bulk job: list jobs: %w", err)
+		}
+		if jobs, err = jobs.Next(); err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// lastModified is the system modstamp of the job, or the created date when there
+// is no modstamp.
+func (r WriteResponse) lastModified() (time.Time, error) {
+	modstamp := r.SystemModstamp
+	if modstamp == "" {
+		modstamp = r.CreatedDate
+	}
+	modified, err := sfdc.ParseTime(modstamp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("bulk job: job %s modified date: %w", r.ID, err)
+	}
+	return modified, nil
+}
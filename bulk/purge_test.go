@@ -0,0 +1,142 @@
+package bulk
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enrique-esquivel/go-sfdc"
+)
+
+func TestResource_PurgeJobs(t *testing.T) {
+	const firstPage = `{"done":false,"nextRecordsUrl":"https://test.salesforce.com/jobs/ingest?page=2","records":[
+		{"id":"complete","state":"JobComplete","jobType":"V2Ingest","systemModstamp":"2020-01-01T00:00:00.000+0000"},
+		{"id":"recent","state":"JobComplete","jobType":"V2Ingest","systemModstamp":"2020-03-01T00:00:00.000+0000"},
+		{"id":"open","state":"Open","jobType":"V2Ingest","systemModstamp":"2020-01-01T00:00:00.000+0000"}]}`
+	const secondPage = `{"done":true,"records":[
+		{"id":"aborted","state":"Aborted","jobType":"V2Ingest","createdDate":"2020-01-01T00:00:00.000+0000"},
+		{"id":"classic","state":"Aborted","jobType":"Classic","systemModstamp":"2020-01-01T00:00:00.000+0000"}]}`
+	const loopPage = `{"done":false,"nextRecordsUrl":"https://test.salesforce.com/jobs/ingest?page=2","records":[
+		{"id":"aborted","state":"Aborted","jobType":"V2Ingest","createdDate":"2020-01-01T00:00:00.000+0000"}]}`
+	const morePage = `{"done":false,"nextRecordsUrl":"https://test.salesforce.com/jobs/ingest?page=3","records":[
+		{"id":"aborted","state":"Aborted","jobType":"V2Ingest","createdDate":"2020-01-01T00:00:00.000+0000"}]}`
+	olderThan := time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		states      []State
+		deleteFails bool
+		page2       string
+		maxPages    int
+		want        int
+		wantDeleted []string
+		wantLoop    bool
+		wantErr     bool
+	}{
+		{
+			name:        "Terminal",
+			want:        2,
+			wantDeleted: []string{"complete", "aborted"},
+			wantErr:     false,
+		},
+		{
+			name:        "Open",
+			states:      []State{Open},
+			want:        1,
+			wantDeleted: []string{"open"},
+			wantErr:     false,
+		},
+		{
+			name:        "Delete Error",
+			deleteFails: true,
+			want:        0,
+			wantDeleted: []string{"complete"},
+			wantErr:     true,
+		},
+		{
+			name:        "Repeated Page",
+			page2:       loopPage,
+			want:        2,
+			wantDeleted: []string{"complete", "aborted"},
+			wantLoop:    true,
+			wantErr:     true,
+		},
+		{
+			name:        "Max Pages",
+			page2:       morePage,
+			maxPages:    1,
+			want:        2,
+			wantDeleted: []string{"complete", "aborted"},
+			wantLoop:    true,
+			wantErr:     true,
+		},
+		{
+			name:        "Max Pages Not Reached",
+			maxPages:    1,
+			want:        2,
+			wantDeleted: []string{"complete", "aborted"},
+			wantErr:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted []string
+			r := &Resource{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.Method == http.MethodDelete {
+							deleted = append(deleted, strings.TrimPrefix(req.URL.Path, "/jobs/ingest/"))
+							if tt.deleteFails {
+								return &http.Response{
+									StatusCode: http.StatusBadRequest,
+									Status:     "Bad Request",
+									Body:       ioutil.NopCloser(strings.NewReader(`[{"errorCode":"INVALIDJOBSTATE","message":"job can not be deleted"}]`)),
+									Header:     make(http.Header),
+								}
+							}
+							return &http.Response{
+								StatusCode: http.StatusNoContent,
+								Status:     "No Content",
+								Body:       ioutil.NopCloser(strings.NewReader("")),
+								Header:     make(http.Header),
+							}
+						}
+						body := firstPage
+						if req.URL.Query().Get("page") == "2" {
+							body = secondPage
+							if tt.page2 != "" {
+								body = tt.page2
+							}
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "OK",
+							Body:       ioutil.NopCloser(strings.NewReader(body)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				MaxPages: tt.maxPages,
+			}
+
+			got, err := r.PurgeJobs(olderThan, tt.states...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.PurgeJobs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if errors.Is(err, sfdc.ErrPaginationLoop) != tt.wantLoop {
+				t.Errorf("Resource.PurgeJobs() error = %v, wantLoop %v", err, tt.wantLoop)
+			}
+			if got != tt.want {
+				t.Errorf("Resource.PurgeJobs() = %v, want %v", got, tt.want)
+			}
+			if reflect.DeepEqual(deleted, tt.wantDeleted) == false {
+				t.Errorf("Resource.PurgeJobs() deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
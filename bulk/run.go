@@ -0,0 +1,92 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// DefaultPollInterval is the interval used to poll a job's state when the
+// job spec does not have one.
+const DefaultPollInterval = 5 * time.Second
+
+// JobSpec is a job to be run by RunJobs.
+//
+// Options are the options used to create the job.
+//
+// Body is the job data that is uploaded.
+//
+// PollInterval is the interval used to poll the job until it completes.  This
+// field is optional.
+type JobSpec struct {
+	Options      Options
+	Body         io.Reader
+	PollInterval time.Duration
+}
+
+// JobResult is the outcome of a job run by RunJobs.  Job is nil when the job
+// could not be created.  Err is the error that stopped the job, including the
+// job failing or being aborted by Salesforce.
+type JobResult struct {
+	Job  *Job
+	Info Info
+	Err  error
+}
+
+// RunJobs will create, upload, close and wait for the completion of each job spec,
+// with at most concurrency jobs in flight.  The results are in the same order
+// as the specs.  If the context is done, the jobs that have not started are not
+// created and their results have the context error.
+func (r *Resource) RunJobs(ctx context.Context, specs []JobSpec, concurrency int) ([]JobResult, error) {
+	if concurrency < 1 {
+		return nil, errors.New("bulk: concurrency must be at least one")
+	}
+
+	results := make([]JobResult, len(specs))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for idx := range specs {
+		if err := ctx.Err(); err != nil {
+			results[idx].Err = err
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			results[idx].Err = ctx.Err()
+			continue
+		case slots <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(idx int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			results[idx] = r.runJob(ctx, specs[idx])
+		}(idx)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+func (r *Resource) runJob(ctx context.Context, spec JobSpec) JobResult {
+	job, err := r.Load(spec.Options, spec.Body)
+	result := JobResult{
+		Job: job,
+	}
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	interval := spec.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	result.Info, result.Err = job.WaitForComplete(ctx, interval)
+	return result
+}
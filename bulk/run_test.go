@@ -0,0 +1,133 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func mockRunJobsClient(t *testing.T, failed string) (*http.Client, func() int) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		mu.Lock()
+		defer mu.Unlock()
+
+		response := func(status int, body string) *http.Response {
+			return &http.Response{
+				StatusCode: status,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Header:     make(http.Header),
+			}
+		}
+		path := strings.TrimPrefix(req.URL.Path, "/jobs/ingest")
+		switch {
+		case req.Method == http.MethodPost && path == "":
+			var options Options
+			if err := json.NewDecoder(req.Body).Decode(&options); err != nil {
+				t.Error(err)
+				return response(http.StatusBadRequest, `[{"message":"bad body","errorCode":"BAD"}]`)
+			}
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			return response(http.StatusOK, `{"id":"`+options.Object+`","state":"Open"}`)
+		case req.Method == http.MethodPut && strings.HasSuffix(path, "/batches"):
+			return response(http.StatusCreated, "")
+		case req.Method == http.MethodPatch:
+			return response(http.StatusOK, `{"id":"`+strings.TrimPrefix(path, "/")+`","state":"UploadComplete"}`)
+		case req.Method == http.MethodGet:
+			id := strings.TrimPrefix(path, "/")
+			inFlight--
+			state := JobComplete
+			if id == failed {
+				state = Failed
+			}
+			return response(http.StatusOK, `{"id":"`+id+`","state":"`+string(state)+`"}`)
+		default:
+			return response(http.StatusBadRequest, `[{"message":"bad request","errorCode":"BAD"}]`)
+		}
+	})
+	return client, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return maxInFlight
+	}
+}
+
+func TestResource_RunJobs(t *testing.T) {
+	client, maxInFlight := mockRunJobsClient(t, "Contact")
+	r := &Resource{
+		session: &mockSessionFormatter{
+			url:    "https://test.salesforce.com",
+			client: client,
+		},
+	}
+	objects := []string{"Account", "Contact", "Lead", "Case", ""}
+	specs := make([]JobSpec, len(objects))
+	for idx, object := range objects {
+		specs[idx] = JobSpec{
+			Options: Options{
+				Object:    object,
+				Operation: Insert,
+			},
+			Body:         strings.NewReader("Name\nsome name\n"),
+			PollInterval: time.Millisecond,
+		}
+	}
+
+	results, err := r.RunJobs(context.Background(), specs, 2)
+	if err != nil {
+		t.Fatalf("Resource.RunJobs() error = %v", err)
+	}
+	if len(results) != len(specs) {
+		t.Fatalf("Resource.RunJobs() = %d results, want %d", len(results), len(specs))
+	}
+	for idx, object := range objects {
+		result := results[idx]
+		switch object {
+		case "":
+			if result.Err == nil || result.Job != nil {
+				t.Errorf("Resource.RunJobs() %d = %v, want create error", idx, result)
+			}
+		case "Contact":
+			if result.Err == nil || result.Info.State != Failed {
+				t.Errorf("Resource.RunJobs() %d = %v, want failed job", idx, result)
+			}
+		default:
+			if result.Err != nil || result.Info.ID != object || result.Info.State != JobComplete {
+				t.Errorf("Resource.RunJobs() %d = %v, want complete job", idx, result)
+			}
+		}
+	}
+	if got := maxInFlight(); got > 2 {
+		t.Errorf("Resource.RunJobs() %d jobs in flight, want at most 2", got)
+	}
+}
+
+func TestResource_RunJobs_Errors(t *testing.T) {
+	r := &Resource{
+		session: &mockSessionFormatter{
+			url: "https://test.salesforce.com",
+		},
+	}
+	if _, err := r.RunJobs(context.Background(), nil, 0); err == nil {
+		t.Error("Resource.RunJobs() error = nil, want concurrency error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := r.RunJobs(ctx, []JobSpec{{}}, 1)
+	if err != nil {
+		t.Fatalf("Resource.RunJobs() error = %v", err)
+	}
+	if results[0].Err != context.Canceled {
+		t.Errorf("Resource.RunJobs() error = %v, want %v", results[0].Err, context.Canceled)
+	}
+}
@@ -0,0 +1,111 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// UploadFromChannel uploads the records that are received from the channel, until
+// it is closed, as they are produced.  The records are written as job data with the
+// column delimiter and line ending of the options, which should be the job's options,
+// and streamed to Salesforce without buffering all of them.
+//
+// The columns are the options' fields, or the sorted fields of the first record.  A
+// field that is not in a record is empty, which leaves the field unchanged, and a
+// record with a field that is not a column fails the upload.
+//
+// If the context is done or the upload fails, the remaining records are not received,
+// so the producer should stop sending when the upload returns.  The records are not
+// received after the upload returns, even when the channel is not closed.
+func (j *Job) UploadFromChannel(ctx context.Context, records <-chan map[string]string, opts Options) error {
+	if records == nil {
+		return errors.New("bulk job: records channel can not be nil")
+	}
+
+	var first map[string]string
+	select {
+	case <-ctx.Done():
+		return jobError(j.WriteResponse.Object, j.WriteResponse.Operation, ctx.Err())
+	case record, ok := <-records:
+		if ok == false {
+			return jobError(j.WriteResponse.Object, j.WriteResponse.Operation, errors.New("no records to upload"))
+		}
+		first = record
+	}
+
+	fields := opts.Fields
+	if len(fields) == 0 {
+		fields = recordFields(first)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeChannel(ctx, writer, fields, first, records, opts))
+	}()
+
+	err := j.upload(ctx, reader)
+	reader.Close()
+	if err != nil {
+		return jobError(j.WriteResponse.Object, j.WriteResponse.Operation, err)
+	}
+	return nil
+}
+
+func recordFields(record map[string]string) []string {
+	fields := make([]string, 0, len(record))
+	for field := range record {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// writeChannel writes the header and the records to the job data, until the
+// channel is closed or the context is done.
+func writeChannel(ctx context.Context, w io.Writer, fields []string, first map[string]string, records <-chan map[string]string, opts Options) error {
+	writer := NewCSVWriter(w, opts)
+	columns := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		columns[field] = struct{}{}
+	}
+
+	if err := writer.Write(fields); err != nil {
+		return err
+	}
+	values := make([]string, len(fields))
+	write := func(record map[string]string) error {
+		for field := range record {
+			if _, has := columns[field]; has == false {
+				return fmt.Errorf("record field %s is not a column", field)
+			}
+		}
+		for idx, field := range fields {
+			values[idx] = record[field]
+		}
+		return writer.Write(values)
+	}
+
+	if err := write(first); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case record, ok := <-records:
+			if ok == false {
+				writer.Flush()
+				return writer.Error()
+			}
+			if err := write(record); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package bulk
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJob_UploadFromChannel(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []map[string]string
+		opts    Options
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "first record fields",
+			records: []map[string]string{
+				{"Name": "Golang", "Site": "Go Site"},
+				{"Name": "Gopher"},
+			},
+			opts: Options{},
+			want: "Name,Site\nGolang,Go Site\nGopher,\n",
+		},
+		{
+			name: "options fields",
+			records: []map[string]string{
+				{"Name": "Golang"},
+				{"Name": "Gopher", "Site": "Go Site"},
+			},
+			opts: Options{
+				ColumnDelimiter: Pipe,
+				LineEnding:      CarriageReturnLinefeed,
+				Fields:          []string{"Site", "Name"},
+			},
+			want: "Site|Name\r\n|Golang\r\nGo Site|Gopher\r\n",
+		},
+		{
+			name: "field not a column",
+			records: []map[string]string{
+				{"Name": "Golang"},
+				{"Name": "Gopher", "Site": "Go Site"},
+			},
+			opts:    Options{},
+			wantErr: true,
+		},
+		{
+			name:    "no records",
+			records: nil,
+			opts:    Options{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			j := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						body, err := ioutil.ReadAll(req.Body)
+						if err != nil {
+							return &http.Response{
+								StatusCode: http.StatusBadRequest,
+								Status:     "Bad Request",
+								Body:       ioutil.NopCloser(strings.NewReader(err.Error())),
+								Header:     make(http.Header),
+							}
+						}
+						got = string(body)
+						return &http.Response{
+							StatusCode: http.StatusCreated,
+							Status:     "Created",
+							Body:       ioutil.NopCloser(strings.NewReader("")),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				WriteResponse: WriteResponse{
+					ID: "1234",
+				},
+			}
+
+			records := make(chan map[string]string)
+			go func() {
+				defer close(records)
+				for _, record := range tt.records {
+					records <- record
+				}
+			}()
+
+			err := j.UploadFromChannel(context.Background(), records, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.UploadFromChannel() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == false && got != tt.want {
+				t.Errorf("Job.UploadFromChannel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_UploadFromChannel_Canceled(t *testing.T) {
+	j := &Job{
+		session: &mockSessionFormatter{
+			url: "https://test.salesforce.com",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				t.Errorf("unexpected upload %s", req.URL.String())
+				return nil
+			}),
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := j.UploadFromChannel(ctx, make(chan map[string]string), Options{}); err == nil {
+		t.Errorf("Job.UploadFromChannel() error = %v, want the context error", err)
+	}
+}
+
+func TestJob_UploadFromChannel_UploadFails(t *testing.T) {
+	j := &Job{
+		session: &mockSessionFormatter{
+			url: "https://test.salesforce.com",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Status:     "Bad Request",
+					Body:       ioutil.NopCloser(strings.NewReader(`[{"errorCode":"INVALIDJOBSTATE","message":"job is not open"}]`)),
+					Header:     make(http.Header),
+				}
+			}),
+		},
+		WriteResponse: WriteResponse{
+			ID:         "1234",
+			ContentURL: "services/data/v42.0/jobs/ingest/1234/batches",
+			State:      Open,
+			Object:     "Account",
+			Operation:  Insert,
+		},
+	}
+
+	before := runtime.NumGoroutine()
+	records := make(chan map[string]string, 1)
+	records <- map[string]string{"Name": "Acme"}
+	if err := j.UploadFromChannel(context.Background(), records, Options{}); err == nil {
+		t.Fatalf("Job.UploadFromChannel() error = %v, want the upload error", err)
+	}
+
+	// The channel is never closed, so the goroutine that writes the records only
+	// returns because the upload did.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("Job.UploadFromChannel() goroutines = %d, want %d", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,145 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WaitForComplete will poll the job information at the interval until the job
+// reaches a terminal state, JobComplete, Failed or Aborted.  An error is returned,
+// along with the job information, when the job failed or was aborted.  If the
+// context is done before the job completes, the context error is returned.
+func (j *Job) WaitForComplete(ctx context.Context, interval time.Duration) (Info, error) {
+	return j.WaitForCompleteFunc(ctx, interval, nil)
+}
+
+// WaitForCompleteFunc is WaitForComplete that calls the poll function with the job
+// information of every poll, which can be used to report progress.  If the poll
+// function returns an error, the wait is stopped and the error is returned.  The
+// poll function can be nil.
+func (j *Job) WaitForCompleteFunc(ctx context.Context, interval time.Duration, poll func(Info) error) (Info, error) {
+	for {
+		info, err := j.Info()
+		if err != nil {
+			return Info{}, err
+		}
+		if poll != nil {
+			if err := poll(info); err != nil {
+				return info, err
+			}
+		}
+		if info.State.Terminal() {
+			return info, info.err()
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return info, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// WaitForCompleteAndAbortOnCancel is WaitForComplete that aborts the job when the
+// context is done before the job completes, so the job does not keep running in
+// the Salesforce org.  The context error is returned, or the abort error if the
+// job could not be aborted.
+func (j *Job) WaitForCompleteAndAbortOnCancel(ctx context.Context, interval time.Duration) (Info, error) {
+	info, err := j.WaitForComplete(ctx, interval)
+	if err == nil || ctx.Err() == nil || info.State.Terminal() {
+		return info, err
+	}
+
+	if _, abortErr := j.Abort(); abortErr != nil {
+		return info, fmt.Errorf("bulk job: abort job %s after %v: %w", j.WriteResponse.ID, err, abortErr)
+	}
+	info.State = Aborted
+	return info, err
+}
+
+// JobErrors are the errors of the jobs that did not complete, by the index of the
+// job.
+type JobErrors map[int]error
+
+func (e JobErrors) Error() string {
+	indexes := make([]int, 0, len(e))
+	for idx := range e {
+		indexes = append(indexes, idx)
+	}
+	sort.Ints(indexes)
+
+	msgs := make([]string, 0, len(e))
+	for _, idx := range indexes {
+		msgs = append(msgs, fmt.Sprintf("job %d: %v", idx, e[idx]))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// WaitForAll will poll the jobs concurrently at the interval until all of them
+// reach a terminal state.  The job information is returned in the same order as
+// the jobs.  The jobs that failed, were aborted or could not be polled are returned
+// as JobErrors, along with the information of all of the jobs.  If the context is
+// done before the jobs complete, the jobs that are still running have the context
+// error.  Since a Job is not safe for concurrent use, a job can only be given once.
+func WaitForAll(ctx context.Context, jobs []*Job, interval time.Duration) ([]Info, error) {
+	given := make(map[*Job]int, len(jobs))
+	for idx, job := range jobs {
+		if job == nil {
+			return nil, fmt.Errorf("bulk job: job %d can not be nil", idx)
+		}
+		if first, has := given[job]; has {
+			return nil, fmt.Errorf("bulk job: job %d is the same job as job %d", idx, first)
+		}
+		given[job] = idx
+	}
+
+	infos := make([]Info, len(jobs))
+	errs := make([]error, len(jobs))
+	var wg sync.WaitGroup
+	for idx := range jobs {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			infos[idx], errs[idx] = jobs[idx].WaitForComplete(ctx, interval)
+		}(idx)
+	}
+	wg.Wait()
+
+	jobErrs := make(JobErrors)
+	for idx, err := range errs {
+		if err != nil {
+			jobErrs[idx] = err
+		}
+	}
+	if len(jobErrs) == 0 {
+		return infos, nil
+	}
+	return infos, jobErrs
+}
+
+// Terminal returns true when the job will not be processed any further.
+func (s State) Terminal() bool {
+	switch s {
+	case JobComplete, Failed, Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+func (i Info) err() error {
+	switch i.State {
+	case Failed:
+		return fmt.Errorf("bulk job: job %s failed: %s", i.ID, i.ErrorMessage)
+	case Aborted:
+		return fmt.Errorf("bulk job: job %s was aborted", i.ID)
+	default:
+		return nil
+	}
+}
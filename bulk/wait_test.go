@@ -0,0 +1,385 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enrique-esquivel/go-sfdc/session"
+)
+
+func mockInfoStates(states ...string) *http.Client {
+	poll := 0
+	return mockHTTPClient(func(req *http.Request) *http.Response {
+		if req.Method != http.MethodGet || req.URL.String() != "https://test.salesforce.com/jobs/ingest/1234" {
+			return &http.Response{
+				StatusCode: 500,
+				Status:     "Invalid URL",
+				Body:       ioutil.NopCloser(strings.NewReader(`[{"message":"invalid url","errorCode":"NOT_FOUND"}]`)),
+				Header:     make(http.Header),
+			}
+		}
+		state := states[len(states)-1]
+		if poll < len(states) {
+			state = states[poll]
+		}
+		poll++
+		resp := `{"id":"1234","state":"` + state + `","errorMessage":"bad things"}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "Good",
+			Body:       ioutil.NopCloser(strings.NewReader(resp)),
+			Header:     make(http.Header),
+		}
+	})
+}
+
+func TestJob_WaitForComplete(t *testing.T) {
+	type fields struct {
+		session session.ServiceFormatter
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		timeout time.Duration
+		want    Info
+		wantErr bool
+	}{
+		{
+			name: "complete",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url:    "https://test.salesforce.com",
+					client: mockInfoStates("UploadComplete", "InProgress", "JobComplete"),
+				},
+			},
+			want: Info{
+				WriteResponse: WriteResponse{
+					ID:    "1234",
+					State: JobComplete,
+				},
+				ErrorMessage: "bad things",
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url:    "https://test.salesforce.com",
+					client: mockInfoStates("InProgress", "Failed"),
+				},
+			},
+			want: Info{
+				WriteResponse: WriteResponse{
+					ID:    "1234",
+					State: Failed,
+				},
+				ErrorMessage: "bad things",
+			},
+			wantErr: true,
+		},
+		{
+			name: "context done",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url:    "https://test.salesforce.com",
+					client: mockInfoStates("InProgress"),
+				},
+			},
+			timeout: 5 * time.Millisecond,
+			want: Info{
+				WriteResponse: WriteResponse{
+					ID:    "1234",
+					State: InProgress,
+				},
+				ErrorMessage: "bad things",
+			},
+			wantErr: true,
+		},
+		{
+			name: "info error",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url:    "https://wrong.salesforce.com",
+					client: mockInfoStates("JobComplete"),
+				},
+			},
+			want:    Info{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			j := &Job{
+				session: tt.fields.session,
+				WriteResponse: WriteResponse{
+					ID: "1234",
+				},
+			}
+			got, err := j.WaitForComplete(ctx, time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.WaitForComplete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Job.WaitForComplete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_WaitForCompleteFunc(t *testing.T) {
+	errTooSlow := errors.New("too slow")
+	tests := []struct {
+		name      string
+		states    []string
+		stopAfter int
+		want      []State
+		wantErr   error
+	}{
+		{
+			name:   "every poll",
+			states: []string{"UploadComplete", "InProgress", "JobComplete"},
+			want:   []State{UpdateComplete, InProgress, JobComplete},
+		},
+		{
+			name:      "stopped",
+			states:    []string{"UploadComplete", "InProgress", "JobComplete"},
+			stopAfter: 2,
+			want:      []State{UpdateComplete, InProgress},
+			wantErr:   errTooSlow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Job{
+				session: &mockSessionFormatter{
+					url:    "https://test.salesforce.com",
+					client: mockInfoStates(tt.states...),
+				},
+				WriteResponse: WriteResponse{
+					ID: "1234",
+				},
+			}
+			var got []State
+			_, err := j.WaitForCompleteFunc(context.Background(), time.Millisecond, func(info Info) error {
+				got = append(got, info.State)
+				if len(got) == tt.stopAfter {
+					return errTooSlow
+				}
+				return nil
+			})
+			if err != tt.wantErr {
+				t.Errorf("Job.WaitForCompleteFunc() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Job.WaitForCompleteFunc() polls = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_WaitForCompleteAndAbortOnCancel(t *testing.T) {
+	tests := []struct {
+		name      string
+		states    []string
+		abort     int
+		timeout   time.Duration
+		wantState State
+		wantAbort bool
+		wantErr   bool
+	}{
+		{
+			name:      "complete",
+			states:    []string{"InProgress", "JobComplete"},
+			abort:     http.StatusOK,
+			wantState: JobComplete,
+			wantAbort: false,
+			wantErr:   false,
+		},
+		{
+			name:      "cancelled",
+			states:    []string{"InProgress"},
+			abort:     http.StatusOK,
+			timeout:   5 * time.Millisecond,
+			wantState: Aborted,
+			wantAbort: true,
+			wantErr:   true,
+		},
+		{
+			name:      "abort error",
+			states:    []string{"InProgress"},
+			abort:     http.StatusBadRequest,
+			timeout:   5 * time.Millisecond,
+			wantState: InProgress,
+			wantAbort: true,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			aborted := false
+			info := mockInfoStates(tt.states...)
+			j := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.Method != http.MethodPatch {
+							response, _ := info.Transport.RoundTrip(req)
+							return response
+						}
+						aborted = true
+						return &http.Response{
+							StatusCode: tt.abort,
+							Status:     "Abort",
+							Body:       ioutil.NopCloser(strings.NewReader(`{"id":"1234","state":"Aborted"}`)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				WriteResponse: WriteResponse{
+					ID: "1234",
+				},
+			}
+			got, err := j.WaitForCompleteAndAbortOnCancel(ctx, time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.WaitForCompleteAndAbortOnCancel() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.State != tt.wantState {
+				t.Errorf("Job.WaitForCompleteAndAbortOnCancel() state = %v, want %v", got.State, tt.wantState)
+			}
+			if aborted != tt.wantAbort {
+				t.Errorf("Job.WaitForCompleteAndAbortOnCancel() aborted = %v, want %v", aborted, tt.wantAbort)
+			}
+		})
+	}
+}
+
+func TestWaitForAll(t *testing.T) {
+	newJob := func(states ...string) *Job {
+		return &Job{
+			session: &mockSessionFormatter{
+				url:    "https://test.salesforce.com",
+				client: mockInfoStates(states...),
+			},
+			WriteResponse: WriteResponse{
+				ID: "1234",
+			},
+		}
+	}
+	sameJob := newJob("JobComplete")
+	tests := []struct {
+		name       string
+		jobs       []*Job
+		wantStates []State
+		wantErrs   []int
+		wantErr    bool
+	}{
+		{
+			name: "complete",
+			jobs: []*Job{
+				newJob("InProgress", "JobComplete"),
+				newJob("UploadComplete", "InProgress", "JobComplete"),
+			},
+			wantStates: []State{JobComplete, JobComplete},
+			wantErr:    false,
+		},
+		{
+			name: "failed",
+			jobs: []*Job{
+				newJob("InProgress", "JobComplete"),
+				newJob("InProgress", "Failed"),
+				newJob("Aborted"),
+			},
+			wantStates: []State{JobComplete, Failed, Aborted},
+			wantErrs:   []int{1, 2},
+			wantErr:    true,
+		},
+		{
+			name:       "no jobs",
+			jobs:       nil,
+			wantStates: []State{},
+			wantErr:    false,
+		},
+		{
+			name:    "nil job",
+			jobs:    []*Job{nil},
+			wantErr: true,
+		},
+		{
+			name:    "same job",
+			jobs:    []*Job{sameJob, newJob("JobComplete"), sameJob},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WaitForAll(context.Background(), tt.jobs, time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WaitForAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantStates == nil {
+				return
+			}
+			states := make([]State, len(got))
+			for idx, info := range got {
+				states[idx] = info.State
+			}
+			if reflect.DeepEqual(states, tt.wantStates) == false {
+				t.Errorf("WaitForAll() = %v, want %v", states, tt.wantStates)
+			}
+			var jobErrs JobErrors
+			if errors.As(err, &jobErrs) {
+				for _, idx := range tt.wantErrs {
+					if jobErrs[idx] == nil {
+						t.Errorf("WaitForAll() job %d error = nil", idx)
+					}
+				}
+				if len(jobErrs) != len(tt.wantErrs) {
+					t.Errorf("WaitForAll() errors = %v, want jobs %v", jobErrs, tt.wantErrs)
+				}
+			}
+		})
+	}
+}
+
+func TestWaitForAll_Canceled(t *testing.T) {
+	job := &Job{
+		session: &mockSessionFormatter{
+			url:    "https://test.salesforce.com",
+			client: mockInfoStates("InProgress"),
+		},
+		WriteResponse: WriteResponse{
+			ID: "1234",
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := WaitForAll(ctx, []*Job{job}, time.Millisecond)
+	var jobErrs JobErrors
+	if errors.As(err, &jobErrs) == false || errors.Is(jobErrs[0], context.DeadlineExceeded) == false {
+		t.Errorf("WaitForAll() error = %v, want the context error", err)
+	}
+}
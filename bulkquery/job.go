@@ -2,14 +2,20 @@ package bulkquery
 
 import (
 	"bytes"
+	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/enrique-esquivel/go-sfdc/internal/csvstruct"
+	"github.com/enrique-esquivel/go-sfdc/internal/pagination"
 	"github.com/enrique-esquivel/go-sfdc/session"
 )
 
@@ -17,8 +23,10 @@ import (
 type QueryJobType string
 
 const (
-	// V2Query is the big objects job.
+	// V2Query is the bulk 2.0 query job.
 	V2Query QueryJobType = "V2Query"
+	// Classic is the bulk 1.0 job.
+	Classic QueryJobType = "Classic"
 )
 
 // ColumnDelimiter is the column delimiter used for CSV job data.
@@ -39,6 +47,12 @@ const (
 	Tab ColumnDelimiter = "TAB"
 )
 
+// Rune returns the character of the column delimiter.  An empty or unknown
+// delimiter is a comma, which is the Salesforce default.
+func (d ColumnDelimiter) Rune() rune {
+	return csvstruct.Delimiter(string(d))
+}
+
 // ContentType is the format of the data being processed.
 type ContentType string
 
@@ -81,6 +95,16 @@ const (
 	Failed State = "Failed"
 )
 
+// Terminal returns true when the job will not be processed any further.
+func (s State) Terminal() bool {
+	switch s {
+	case JobComplete, Failed, Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
 const (
 	// sfID is the column name for the Salesforce Object ID in Job CSV responses
 	sfID = "sf__Id"
@@ -101,17 +125,23 @@ const (
 // LineEnding is the line ending used for the CSV job data.  This field is optional.
 //
 // QueryOptions is the processing operation for the job. This field is required.
+//
+// JobType is the type of the job, which can only be V2Query, since the bulk 1.0
+// jobs can not be created with the bulk 2.0 API.  It is sent so the job type does
+// not depend on the Salesforce default.  This field is optional and defaults to
+// V2Query.
 type QueryOptions struct {
 	ColumnDelimiter ColumnDelimiter `json:"columnDelimiter"`
 	ContentType     ContentType     `json:"contentType"`
 	LineEnding      LineEnding      `json:"lineEnding"`
 	Query           string          `json:"query"`
 	Operation       QueryOperation  `json:"operation"`
+	JobType         QueryJobType    `json:"jobType,omitempty"`
 }
 
 // QueryResponse is the response to job APIs.
 type QueryResponse struct {
-	APIVersion      float32         `json:"apiVersion"`
+	APIVersion      sfdc.APIVersion `json:"apiVersion"`
 	ColumnDelimiter ColumnDelimiter `json:"columnDelimiter"`
 	ConcurrencyMode string          `json:"concurrencyMode"`
 	ContentType     string          `json:"contentType"`
@@ -134,10 +164,63 @@ type QueryInfo struct {
 	TotalProcessingTime    int `json:"totalProcessingTime"`
 }
 
+// ParseOptions are the options used when requesting and parsing the job results.
+//
+// Accept is the Accept header of the results requests, which defaults to text/csv.
+//
+// LazyQuotes allows quotes in unquoted fields and unescaped quotes in quoted fields,
+// which Salesforce can return in the field values.
+//
+// FieldsPerRecord is the number of fields of every row.  If zero, the rows must have
+// as many fields as the header, and if negative, the rows can have any number of fields.
+//
+// CSVReader is called with the CSV reader of the results, after the column delimiter
+// and the options are set, so the reader can be configured further.
+type ParseOptions struct {
+	Accept          string
+	LazyQuotes      bool
+	FieldsPerRecord int
+	CSVReader       func(*csv.Reader)
+}
+
 // QueryJob is the bulk job.
+//
+// MaxPages is the most pages of results that AllRecords exports.  If zero,
+// sfdc.DefaultMaxPages is used.
 type QueryJob struct {
 	session       session.ServiceFormatter
 	QueryResponse QueryResponse
+	ParseOptions  ParseOptions
+	MaxPages      int
+	processed     *int
+}
+
+const defaultResultsAccept = "text/csv"
+
+func (j *QueryJob) accept() string {
+	if j.ParseOptions.Accept != "" {
+		return j.ParseOptions.Accept
+	}
+	return defaultResultsAccept
+}
+
+// NewCSVReader returns a CSV reader configured with the column delimiter of the
+// job and the parse options, to read the results that are written by Export or
+// WriteResults.  The results are delimited with the job's delimiter, so a reader
+// with the default comma would split the fields of a TAB job incorrectly.
+func (j *QueryJob) NewCSVReader(r io.Reader) *csv.Reader {
+	return j.csvReader(r)
+}
+
+func (j *QueryJob) csvReader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.Comma = j.delimiter()
+	reader.LazyQuotes = j.ParseOptions.LazyQuotes
+	reader.FieldsPerRecord = j.ParseOptions.FieldsPerRecord
+	if j.ParseOptions.CSVReader != nil {
+		j.ParseOptions.CSVReader(reader)
+	}
+	return reader
 }
 
 func (j *QueryJob) create(options QueryOptions) error {
@@ -153,29 +236,82 @@ func (j *QueryJob) create(options QueryOptions) error {
 	return nil
 }
 
+// Validate checks that the options are able to create a query job, without
+// calling out to Salesforce.
+func (o QueryOptions) Validate() error {
+	if errs := o.validate(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ValidateAll checks the options like Validate, but returns all of the problems
+// as sfdc.ValidationErrors instead of only the first one.
+func (o QueryOptions) ValidateAll() error {
+	return o.validate().Err()
+}
+
+func (o QueryOptions) validate() sfdc.ValidationErrors {
+	var errs sfdc.ValidationErrors
+	query := strings.TrimSpace(o.Query)
+	if query == "" {
+		errs = append(errs, errors.New("bulk job: query is required"))
+	} else if fields := strings.Fields(query); strings.EqualFold(fields[0], "SELECT") == false {
+		errs = append(errs, errors.New("bulk job: query must start with SELECT"))
+	}
+	switch o.Operation {
+	case "", Query, QueryAll:
+	default:
+		errs = append(errs, fmt.Errorf("bulk job: %s is not a query operation", o.Operation))
+	}
+	switch o.JobType {
+	case "", V2Query:
+	default:
+		errs = append(errs, fmt.Errorf("bulk job: %s is not a bulk 2.0 query job type", o.JobType))
+	}
+	switch o.ColumnDelimiter {
+	case "", Backquote, Caret, Comma, Pipe, SemiColon, Tab:
+	default:
+		errs = append(errs, fmt.Errorf("bulk job: %s is not a column delimiter", o.ColumnDelimiter))
+	}
+	return errs
+}
+
 func (j *QueryJob) formatOptions(options *QueryOptions) error {
-	if options.Query == "" {
-		return errors.New("bulk job: query is required")
+	if err := options.Validate(); err != nil {
+		return err
 	}
+	options.Normalize()
+	return nil
+}
+
+// Normalize applies the defaults to the options that are not set, which are the
+// options that will be submitted when the job is created.  The line ending defaults
+// to LF, the content type to CSV, the column delimiter to COMMA, the operation to query
+// and the job type to V2Query.
+// The leading and trailing whitespace of the query is removed.
+func (o *QueryOptions) Normalize() {
+	o.Query = strings.TrimSpace(o.Query)
 
-	// defaults
-	if options.LineEnding == "" {
-		options.LineEnding = Linefeed
+	if o.LineEnding == "" {
+		o.LineEnding = Linefeed
 	}
 
-	if options.ContentType == "" {
-		options.ContentType = CSV
+	if o.ContentType == "" {
+		o.ContentType = CSV
 	}
 
-	if options.ColumnDelimiter == "" {
-		options.ColumnDelimiter = Comma
+	if o.ColumnDelimiter == "" {
+		o.ColumnDelimiter = Comma
 	}
 
-	if options.Operation == "" {
-		options.Operation = Query
+	if o.Operation == "" {
+		o.Operation = Query
 	}
 
-	return nil
+	if o.JobType == "" {
+		o.JobType = V2Query
+	}
 }
 
 func (j *QueryJob) createCallout(options QueryOptions) (QueryResponse, error) {
@@ -191,6 +327,9 @@ func (j *QueryJob) createCallout(options QueryOptions) (QueryResponse, error) {
 	request.Header.Add("Accept", "application/json")
 	request.Header.Add("Content-Type", "application/json")
 	j.session.AuthorizationHeader(request)
+	request = sfdc.WithTraceAttributes(request, map[string]string{
+		sfdc.TraceOperation: string(options.Operation),
+	})
 
 	return j.response(request)
 }
@@ -219,7 +358,22 @@ func (j *QueryJob) response(request *http.Request) (QueryResponse, error) {
 	return value, nil
 }
 
+// MaxResultsPerPage is the largest page of query results that Export requests.
+// Salesforce does not document a maximum for the page size, and returns smaller
+// pages when a page would be too large, so this is the package's guardrail against
+// a page size that is meant to return all of the results at once, rather than a
+// Salesforce limit.  The results of a larger query are exported page by page with
+// the locator.
+const MaxResultsPerPage = 1000000
+
 // ExportInfo configure export
+//
+// MaxRecords is the number of records in a page of results, which can not be
+// negative or more than MaxResultsPerPage.  If zero, the page size is chosen by
+// Salesforce.
+//
+// Locator is the page of results to export.  It is updated with the locator of the next
+// page, which Salesforce returns as "null" when there are no more results.
 type ExportInfo struct {
 	Writer     io.Writer
 	MaxRecords int
@@ -228,6 +382,10 @@ type ExportInfo struct {
 
 // Export exports results of query job
 func (j *QueryJob) Export(i *ExportInfo) error {
+	if i.MaxRecords < 0 || i.MaxRecords > MaxResultsPerPage {
+		return fmt.Errorf("bulk job: max records must be between 0 and %d", MaxResultsPerPage)
+	}
+
 	url := j.session.ServiceURL() + bulk2Endpoint + "/" + j.QueryResponse.ID + "/results"
 	request, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
@@ -244,9 +402,12 @@ func (j *QueryJob) Export(i *ExportInfo) error {
 
 	request.URL.RawQuery = q.Encode()
 
-	request.Header.Add("Accept", "text/csv")
+	request.Header.Add("Accept", j.accept())
 	request.Header.Add("Content-Type", "application/json")
+	sfdc.AcceptGzip(request)
 	j.session.AuthorizationHeader(request)
+	request = sfdc.WithTraceAttributes(request, j.traceAttributes())
+	request = sfdc.WithTraceAttributes(request, j.recordAttributes())
 
 	response, err := j.session.Client().Do(request)
 	if err != nil {
@@ -254,6 +415,11 @@ func (j *QueryJob) Export(i *ExportInfo) error {
 	}
 	defer response.Body.Close()
 
+	err = sfdc.DecompressResponse(response)
+	if err != nil {
+		return err
+	}
+
 	if response.StatusCode != http.StatusOK {
 		err := sfdc.HandleError(response)
 		return err
@@ -269,8 +435,85 @@ func (j *QueryJob) Export(i *ExportInfo) error {
 	return nil
 }
 
+// noLocator is the locator Salesforce returns for the last page of results.
+const noLocator = "null"
+
+// AllRecords will export every page of the job results and return the records of
+// all of the pages.  The pageSize is the number of records in each page, see
+// ExportInfo.  The pages are exported until the locator is "null", which Salesforce
+// returns for the last page.  The context is checked before each page is exported.
+// The results are parsed with the column delimiter of the job, which is retrieved with Info
+// when it is not known.
+func (j *QueryJob) AllRecords(ctx context.Context, pageSize int) ([]map[string]string, error) {
+	if j.QueryResponse.ColumnDelimiter == "" {
+		if _, err := j.Info(); err != nil {
+			return nil, err
+		}
+	}
+
+	var records []map[string]string
+	locator := ""
+	guard := pagination.Guard{MaxPages: j.MaxPages}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := guard.Follow(locator); err != nil {
+			return nil, fmt.Errorf("bulk query job: locator: %w", err)
+		}
+
+		var page bytes.Buffer
+		info := ExportInfo{
+			Writer:     &page,
+			MaxRecords: pageSize,
+			Locator:    locator,
+		}
+		if err := j.Export(&info); err != nil {
+			return nil, err
+		}
+		pageRecords, err := j.parseRecords(&page)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, pageRecords...)
+
+		if info.Locator == "" || info.Locator == noLocator {
+			return records, nil
+		}
+		locator = info.Locator
+	}
+}
+
+// parseRecords parses a page of results, which starts with the header.
+func (j *QueryJob) parseRecords(page io.Reader) ([]map[string]string, error) {
+	reader := j.csvReader(page)
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	fields := j.fields(header, 0)
+
+	var records []map[string]string
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, j.record(fields, values))
+	}
+	return records, nil
+}
+
 // ExportResults exports the job results to a local file
 // returns the next locator (if more results are available)
+// maxRecords is the page size, see ExportInfo
 func (j *QueryJob) ExportResults(filepath string, maxRecords int, locator string) (string, error) {
 	// Create the file
 	out, err := os.Create(filepath)
@@ -280,8 +523,15 @@ func (j *QueryJob) ExportResults(filepath string, maxRecords int, locator string
 
 	defer out.Close()
 
+	return j.WriteResults(out, maxRecords, locator)
+}
+
+// WriteResults writes the job results to the writer
+// returns the next locator (if more results are available)
+// maxRecords is the page size, see ExportInfo
+func (j *QueryJob) WriteResults(w io.Writer, maxRecords int, locator string) (string, error) {
 	info := ExportInfo{
-		Writer:     out,
+		Writer:     w,
 		MaxRecords: maxRecords,
 		Locator:    locator,
 	}
@@ -307,6 +557,11 @@ func (j *QueryJob) fetchInfo(id string) (QueryInfo, error) {
 	request.Header.Add("Accept", "application/json")
 	request.Header.Add("Content-Type", "application/json")
 	j.session.AuthorizationHeader(request)
+	request = sfdc.WithTraceAttributes(request, j.traceAttributes())
+	request = sfdc.WithTraceAttributes(request, j.recordAttributes())
+	request = sfdc.WithTraceAttributes(request, map[string]string{
+		sfdc.TraceJobID: id,
+	})
 
 	return j.infoResponse(request)
 }
@@ -331,6 +586,7 @@ func (j *QueryJob) infoResponse(request *http.Request) (QueryInfo, error) {
 	}
 
 	j.QueryResponse = value.QueryResponse
+	j.processed = &value.NumberRecordsProcessed
 
 	return value, nil
 }
@@ -353,6 +609,7 @@ func (j *QueryJob) setState(state State) (QueryResponse, error) {
 	request.Header.Add("Accept", "application/json")
 	request.Header.Add("Content-Type", "application/json")
 	j.session.AuthorizationHeader(request)
+	request = sfdc.WithTraceAttributes(request, j.traceAttributes())
 
 	return j.response(request)
 }
@@ -370,18 +627,36 @@ func (j *QueryJob) Delete() error {
 		return err
 	}
 	j.session.AuthorizationHeader(request)
+	request = sfdc.WithTraceAttributes(request, j.traceAttributes())
 
 	response, err := j.session.Client().Do(request)
 	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return errors.New("job error: unable to delete job")
+		return sfdc.HandleError(response)
 	}
 	return nil
 }
 
+// Cancel aborts the job and then deletes it, with its results, so an ad-hoc query
+// job does not remain in the org's job list.  A job that has already completed,
+// failed or been aborted is only deleted.  If the abort fails because the job
+// reached a terminal state in the meantime, the job is still deleted.
+func (j *QueryJob) Cancel() error {
+	if j.QueryResponse.State.Terminal() == false {
+		if _, err := j.Abort(); err != nil {
+			info, infoErr := j.Info()
+			if infoErr != nil || info.State.Terminal() == false {
+				return err
+			}
+		}
+	}
+	return j.Delete()
+}
+
 func (j *QueryJob) headerPosition(column string, header []string) int {
 	for idx, col := range header {
 		if col == column {
@@ -400,24 +675,34 @@ func (j *QueryJob) fields(header []string, offset int) []string {
 func (j *QueryJob) record(fields, values []string) map[string]string {
 	record := make(map[string]string)
 	for idx, field := range fields {
-		record[field] = values[idx]
+		if idx < len(values) {
+			record[field] = values[idx]
+		} else {
+			record[field] = ""
+		}
 	}
 	return record
 }
 
 func (j *QueryJob) delimiter() rune {
-	switch ColumnDelimiter(j.QueryResponse.ColumnDelimiter) {
-	case Tab:
-		return '\t'
-	case SemiColon:
-		return ';'
-	case Pipe:
-		return '|'
-	case Caret:
-		return '^'
-	case Backquote:
-		return '`'
-	default:
-		return ','
+	return j.QueryResponse.ColumnDelimiter.Rune()
+}
+
+func (j *QueryJob) traceAttributes() map[string]string {
+	return map[string]string{
+		sfdc.TraceObject:    j.QueryResponse.Object,
+		sfdc.TraceOperation: string(j.QueryResponse.Operation),
+		sfdc.TraceJobID:     j.QueryResponse.ID,
+	}
+}
+
+// recordAttributes are the trace attributes of the number of records processed by
+// the job information that was last retrieved, which are empty until it is retrieved.
+func (j *QueryJob) recordAttributes() map[string]string {
+	if j.processed == nil {
+		return nil
+	}
+	return map[string]string{
+		sfdc.TraceRecordsProcessed: strconv.Itoa(*j.processed),
 	}
 }
@@ -0,0 +1,655 @@
+package bulkquery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/enrique-esquivel/go-sfdc"
+)
+
+func TestQueryOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options QueryOptions
+		wantErr bool
+	}{
+		{
+			name: "Passing",
+			options: QueryOptions{
+				Query:           "SELECT Id FROM Account",
+				ColumnDelimiter: Tab,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Default Delimiter",
+			options: QueryOptions{
+				Query: "SELECT Id FROM Account",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Classic Job Type",
+			options: QueryOptions{
+				Query:   "SELECT Id FROM Account",
+				JobType: Classic,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown Delimiter",
+			options: QueryOptions{
+				Query:           "SELECT Id FROM Account",
+				ColumnDelimiter: ColumnDelimiter("\t"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Lower Case Select",
+			options: QueryOptions{
+				Query: "\n  select Id FROM Account\n",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "No Query",
+			options: QueryOptions{},
+			wantErr: true,
+		},
+		{
+			name: "Blank Query",
+			options: QueryOptions{
+				Query: " \n\t",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not A Select",
+			options: QueryOptions{
+				Query: "DELETE FROM Account",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Select Prefix",
+			options: QueryOptions{
+				Query: "SELECTED Id FROM Account",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Query All",
+			options: QueryOptions{
+				Query:     "SELECT Id FROM Account",
+				Operation: QueryAll,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Not A Query Operation",
+			options: QueryOptions{
+				Query:     "SELECT Id FROM Account",
+				Operation: QueryOperation("insert"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.options.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("QueryOptions.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQueryJob_AllRecords_Delimiters(t *testing.T) {
+	want := []map[string]string{
+		{
+			"Name":        "Smith, Jr",
+			"Description": "Tab\tPipe|Caret^Semi;Back`",
+		},
+	}
+	tests := []struct {
+		name      string
+		delimiter ColumnDelimiter
+		results   string
+	}{
+		{
+			name:      "Backquote",
+			delimiter: Backquote,
+			results:   "Name`Description\nSmith, Jr`\"Tab\tPipe|Caret^Semi;Back`\"\n",
+		},
+		{
+			name:      "Caret",
+			delimiter: Caret,
+			results:   "Name^Description\nSmith, Jr^\"Tab\tPipe|Caret^Semi;Back`\"\n",
+		},
+		{
+			name:      "Comma",
+			delimiter: Comma,
+			results:   "Name,Description\n\"Smith, Jr\",Tab\tPipe|Caret^Semi;Back`\n",
+		},
+		{
+			name:      "Pipe",
+			delimiter: Pipe,
+			results:   "Name|Description\nSmith, Jr|\"Tab\tPipe|Caret^Semi;Back`\"\n",
+		},
+		{
+			name:      "SemiColon",
+			delimiter: SemiColon,
+			results:   "Name;Description\nSmith, Jr;\"Tab\tPipe|Caret^Semi;Back`\"\n",
+		},
+		{
+			name:      "Tab",
+			delimiter: Tab,
+			results:   "Name\tDescription\nSmith, Jr\t\"Tab\tPipe|Caret^Semi;Back`\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			infos := 0
+			job := &QueryJob{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						header := make(http.Header)
+						if strings.HasSuffix(req.URL.Path, "/results") {
+							header.Set("Sforce-Locator", "null")
+							return &http.Response{
+								StatusCode: http.StatusOK,
+								Status:     "OK",
+								Body:       ioutil.NopCloser(strings.NewReader(tt.results)),
+								Header:     header,
+							}
+						}
+						infos++
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "OK",
+							Body:       ioutil.NopCloser(strings.NewReader(fmt.Sprintf(`{"id":"1234","columnDelimiter":"%s"}`, tt.delimiter))),
+							Header:     header,
+						}
+					}),
+				},
+				QueryResponse: QueryResponse{
+					ID: "1234",
+				},
+			}
+
+			got, err := job.AllRecords(context.Background(), 0)
+			if err != nil {
+				t.Fatalf("QueryJob.AllRecords() error = %v", err)
+			}
+			if infos != 1 || job.QueryResponse.ColumnDelimiter != tt.delimiter {
+				t.Errorf("QueryJob.AllRecords() infos = %d, delimiter = %v", infos, job.QueryResponse.ColumnDelimiter)
+			}
+			if reflect.DeepEqual(got, want) == false {
+				t.Errorf("QueryJob.AllRecords() = %v, want %v", got, want)
+			}
+
+			reader := job.NewCSVReader(strings.NewReader(tt.results))
+			records, err := reader.ReadAll()
+			if err != nil {
+				t.Fatalf("QueryJob.NewCSVReader() error = %v", err)
+			}
+			if len(records) != 2 || len(records[1]) != 2 {
+				t.Errorf("QueryJob.NewCSVReader() = %v", records)
+			}
+		})
+	}
+}
+
+func TestQueryJob_Cancel(t *testing.T) {
+	tests := []struct {
+		name         string
+		state        State
+		abortStatus  int
+		infoState    State
+		wantRequests []string
+		wantErr      bool
+	}{
+		{
+			name:         "Open",
+			state:        Open,
+			abortStatus:  http.StatusOK,
+			wantRequests: []string{http.MethodPatch, http.MethodDelete},
+			wantErr:      false,
+		},
+		{
+			name:         "Complete",
+			state:        JobComplete,
+			wantRequests: []string{http.MethodDelete},
+			wantErr:      false,
+		},
+		{
+			name:         "Completed Before Abort",
+			state:        UpdateComplete,
+			abortStatus:  http.StatusBadRequest,
+			infoState:    JobComplete,
+			wantRequests: []string{http.MethodPatch, http.MethodGet, http.MethodDelete},
+			wantErr:      false,
+		},
+		{
+			name:         "Abort Error",
+			state:        UpdateComplete,
+			abortStatus:  http.StatusBadRequest,
+			infoState:    UpdateComplete,
+			wantRequests: []string{http.MethodPatch, http.MethodGet},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			job := &QueryJob{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						requests = append(requests, req.Method)
+						if req.URL.String() != "https://test.salesforce.com/jobs/query/1234" {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid URL",
+								Body:       ioutil.NopCloser(strings.NewReader(req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+						switch req.Method {
+						case http.MethodPatch:
+							body := `{"id":"1234","state":"Aborted"}`
+							if tt.abortStatus != http.StatusOK {
+								body = `[{"errorCode":"INVALIDJOBSTATE","message":"job is already complete"}]`
+							}
+							return &http.Response{
+								StatusCode: tt.abortStatus,
+								Status:     http.StatusText(tt.abortStatus),
+								Body:       ioutil.NopCloser(strings.NewReader(body)),
+								Header:     make(http.Header),
+							}
+						case http.MethodGet:
+							return &http.Response{
+								StatusCode: http.StatusOK,
+								Status:     "OK",
+								Body:       ioutil.NopCloser(strings.NewReader(fmt.Sprintf(`{"id":"1234","state":"%s"}`, tt.infoState))),
+								Header:     make(http.Header),
+							}
+						default:
+							return &http.Response{
+								StatusCode: http.StatusNoContent,
+								Status:     "No Content",
+								Body:       ioutil.NopCloser(strings.NewReader("")),
+								Header:     make(http.Header),
+							}
+						}
+					}),
+				},
+				QueryResponse: QueryResponse{
+					ID:    "1234",
+					State: tt.state,
+				},
+			}
+
+			err := job.Cancel()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("QueryJob.Cancel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if reflect.DeepEqual(requests, tt.wantRequests) == false {
+				t.Errorf("QueryJob.Cancel() requests = %v, want %v", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestQueryJob_Export_Locator(t *testing.T) {
+	tests := []struct {
+		name    string
+		locator string
+		want    string
+	}{
+		{
+			name:    "Next Page",
+			locator: "MTAwMDA",
+			want:    "MTAwMDA",
+		},
+		{
+			name:    "Last Page",
+			locator: "null",
+			want:    "null",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &QueryJob{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						header := make(http.Header)
+						header.Set("Sforce-Locator", tt.locator)
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "OK",
+							Body:       ioutil.NopCloser(strings.NewReader("Id\n1234\n")),
+							Header:     header,
+						}
+					}),
+				},
+				QueryResponse: QueryResponse{
+					ID: "1234",
+				},
+			}
+			sb := &strings.Builder{}
+			got, err := job.WriteResults(sb, 0, "")
+			if err != nil {
+				t.Fatalf("QueryJob.WriteResults() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("QueryJob.WriteResults() locator = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryJob_WriteResults(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxRecords  int
+		locator     string
+		status      int
+		wantQuery   string
+		want        string
+		wantResults string
+		wantErr     bool
+	}{
+		{
+			name:        "First Page",
+			status:      http.StatusOK,
+			wantQuery:   "",
+			want:        "MTAwMDA",
+			wantResults: "Id\n1234\n",
+			wantErr:     false,
+		},
+		{
+			name:        "Page",
+			maxRecords:  100,
+			locator:     "MTAwMDA",
+			status:      http.StatusOK,
+			wantQuery:   "locator=MTAwMDA&maxRecords=100",
+			want:        "MTAwMDA",
+			wantResults: "Id\n1234\n",
+			wantErr:     false,
+		},
+		{
+			name:       "Negative Max Records",
+			maxRecords: -1,
+			status:     http.StatusOK,
+			wantErr:    true,
+		},
+		{
+			name:        "Max Records",
+			maxRecords:  MaxResultsPerPage,
+			status:      http.StatusOK,
+			wantQuery:   "maxRecords=1000000",
+			want:        "MTAwMDA",
+			wantResults: "Id\n1234\n",
+			wantErr:     false,
+		},
+		{
+			name:       "Over Max Records",
+			maxRecords: MaxResultsPerPage + 1,
+			status:     http.StatusOK,
+			wantErr:    true,
+		},
+		{
+			name:    "Error Response",
+			status:  http.StatusBadRequest,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &QueryJob{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.Path != "/jobs/query/1234/results" || req.URL.RawQuery != tt.wantQuery {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid Request",
+								Body:       ioutil.NopCloser(strings.NewReader(req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+						if tt.status != http.StatusOK {
+							return &http.Response{
+								StatusCode: tt.status,
+								Status:     http.StatusText(tt.status),
+								Body:       ioutil.NopCloser(strings.NewReader(`[{"errorCode":"INVALIDJOB","message":"job not found"}]`)),
+								Header:     make(http.Header),
+							}
+						}
+						header := make(http.Header)
+						header.Set("Sforce-Locator", "MTAwMDA")
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "OK",
+							Body:       ioutil.NopCloser(strings.NewReader("Id\n1234\n")),
+							Header:     header,
+						}
+					}),
+				},
+				QueryResponse: QueryResponse{
+					ID: "1234",
+				},
+			}
+			sb := &strings.Builder{}
+			got, err := job.WriteResults(sb, tt.maxRecords, tt.locator)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("QueryJob.WriteResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("QueryJob.WriteResults() locator = %v, want %v", got, tt.want)
+			}
+			if sb.String() != tt.wantResults {
+				t.Errorf("QueryJob.WriteResults() results = %q, want %q", sb.String(), tt.wantResults)
+			}
+		})
+	}
+}
+
+func TestQueryJob_AllRecords(t *testing.T) {
+	tests := []struct {
+		name         string
+		pages        map[string]string
+		locators     map[string]string
+		maxPages     int
+		ctx          func() context.Context
+		want         []map[string]string
+		wantLocators []string
+		wantLoop     bool
+		wantErr      bool
+	}{
+		{
+			name: "Pages",
+			pages: map[string]string{
+				"":     "Id,Name\n1,Acme\n",
+				"page": "Id,Name\n2,Golang\n",
+			},
+			locators: map[string]string{
+				"":     "page",
+				"page": "null",
+			},
+			want: []map[string]string{
+				{"Id": "1", "Name": "Acme"},
+				{"Id": "2", "Name": "Golang"},
+			},
+			wantLocators: []string{"", "page"},
+			wantErr:      false,
+		},
+		{
+			name: "Empty Locator",
+			pages: map[string]string{
+				"": "Id,Name\n1,Acme\n",
+			},
+			locators: map[string]string{},
+			want: []map[string]string{
+				{"Id": "1", "Name": "Acme"},
+			},
+			wantLocators: []string{""},
+			wantErr:      false,
+		},
+		{
+			name: "Repeated Locator",
+			pages: map[string]string{
+				"":     "Id,Name\n1,Acme\n",
+				"page": "Id,Name\n2,Golang\n",
+			},
+			locators: map[string]string{
+				"":     "page",
+				"page": "page",
+			},
+			wantLocators: []string{"", "page"},
+			wantLoop:     true,
+			wantErr:      true,
+		},
+		{
+			name: "Max Pages",
+			pages: map[string]string{
+				"":     "Id,Name\n1,Acme\n",
+				"page": "Id,Name\n2,Golang\n",
+			},
+			locators: map[string]string{
+				"":     "page",
+				"page": "null",
+			},
+			maxPages:     1,
+			wantLocators: []string{""},
+			wantLoop:     true,
+			wantErr:      true,
+		},
+		{
+			name: "Export Error",
+			pages: map[string]string{
+				"": "Id,Name\n1,Acme\n",
+			},
+			locators: map[string]string{
+				"": "missing",
+			},
+			wantLocators: []string{"", "missing"},
+			wantErr:      true,
+		},
+		{
+			name: "Canceled",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var locators []string
+			job := &QueryJob{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						locator := req.URL.Query().Get("locator")
+						locators = append(locators, locator)
+						page, ok := tt.pages[locator]
+						if ok == false {
+							return &http.Response{
+								StatusCode: http.StatusNotFound,
+								Status:     "Not Found",
+								Body:       ioutil.NopCloser(strings.NewReader(`[{"errorCode":"NOT_FOUND","message":"locator not found"}]`)),
+								Header:     make(http.Header),
+							}
+						}
+						header := make(http.Header)
+						header.Set("Sforce-Locator", tt.locators[locator])
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "OK",
+							Body:       ioutil.NopCloser(strings.NewReader(page)),
+							Header:     header,
+						}
+					}),
+				},
+				QueryResponse: QueryResponse{
+					ID:              "1234",
+					ColumnDelimiter: Comma,
+				},
+				MaxPages: tt.maxPages,
+			}
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx()
+			}
+
+			got, err := job.AllRecords(ctx, 0)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("QueryJob.AllRecords() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if errors.Is(err, sfdc.ErrPaginationLoop) != tt.wantLoop {
+				t.Errorf("QueryJob.AllRecords() error = %v, wantLoop %v", err, tt.wantLoop)
+			}
+			if reflect.DeepEqual(got, tt.want) == false {
+				t.Errorf("QueryJob.AllRecords() = %v, want %v", got, tt.want)
+			}
+			if reflect.DeepEqual(locators, tt.wantLocators) == false {
+				t.Errorf("QueryJob.AllRecords() locators = %v, want %v", locators, tt.wantLocators)
+			}
+		})
+	}
+}
+
+func TestQueryJob_recordAttributes(t *testing.T) {
+	var processed []string
+	job := &QueryJob{
+		session: &mockSessionFormatter{
+			url: "https://test.salesforce.com",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				processed = append(processed, sfdc.TraceAttributes(req)[sfdc.TraceRecordsProcessed])
+				if req.URL.Path == "/jobs/query/1234" {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Status:     "OK",
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id":"1234","state":"JobComplete","numberRecordsProcessed":10}`)),
+						Header:     make(http.Header),
+					}
+				}
+				header := make(http.Header)
+				header.Set("Sforce-Locator", "null")
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Status:     "OK",
+					Body:       ioutil.NopCloser(strings.NewReader("Id\n1234\n")),
+					Header:     header,
+				}
+			}),
+		},
+		QueryResponse: QueryResponse{
+			ID: "1234",
+		},
+	}
+
+	if _, err := job.Info(); err != nil {
+		t.Fatalf("QueryJob.Info() error = %v", err)
+	}
+	if _, err := job.WriteResults(ioutil.Discard, 0, ""); err != nil {
+		t.Fatalf("QueryJob.WriteResults() error = %v", err)
+	}
+	want := []string{"", "10"}
+	if reflect.DeepEqual(processed, want) == false {
+		t.Errorf("QueryJob trace records processed = %v, want %v", processed, want)
+	}
+}
@@ -0,0 +1,141 @@
+package bulkquery
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/enrique-esquivel/go-sfdc/session"
+)
+
+// JobListParams are the filters to query all of the bulk query jobs.  Only the
+// filters that are set are sent.
+//
+// IsPkChunkingEnabled will filter jobs with PK chunking enabled.
+//
+// JobType will filter jobs based on job type.
+//
+// ConcurrencyMode will filter jobs based on the concurrency mode, like Parallel.
+//
+// QueryLocator will start the listing from the locator of a previous page.
+type JobListParams struct {
+	IsPkChunkingEnabled *bool
+	JobType             QueryJobType
+	ConcurrencyMode     string
+	QueryLocator        string
+}
+
+func (p JobListParams) values() map[string]string {
+	values := make(map[string]string)
+	if p.IsPkChunkingEnabled != nil {
+		values["isPkChunkingEnabled"] = strconv.FormatBool(*p.IsPkChunkingEnabled)
+	}
+	if p.JobType != "" {
+		values["jobType"] = string(p.JobType)
+	}
+	if p.ConcurrencyMode != "" {
+		values["concurrencyMode"] = p.ConcurrencyMode
+	}
+	if p.QueryLocator != "" {
+		values["queryLocator"] = p.QueryLocator
+	}
+	return values
+}
+
+type jobResponse struct {
+	Done           bool            `json:"done"`
+	Records        []QueryResponse `json:"records"`
+	NextRecordsURL string          `json:"nextRecordsUrl"`
+}
+
+// Jobs presents the response from the all jobs request.
+type Jobs struct {
+	session  session.ServiceFormatter
+	response jobResponse
+}
+
+func newJobs(session session.ServiceFormatter, parameters JobListParams) (*Jobs, error) {
+	j := &Jobs{
+		session: session,
+	}
+	url := session.ServiceURL() + bulk2Endpoint
+	request, err := j.request(url)
+	if err != nil {
+		return nil, err
+	}
+	q := request.URL.Query()
+	for key, value := range parameters.values() {
+		q.Add(key, value)
+	}
+	request.URL.RawQuery = q.Encode()
+
+	response, err := j.do(request)
+	if err != nil {
+		return nil, err
+	}
+	j.response = response
+	return j, nil
+}
+
+// Done indicates whether there are more jobs to get.
+func (j *Jobs) Done() bool {
+	return j.response.Done
+}
+
+// Records contains the information for each retrieved job.
+func (j *Jobs) Records() []QueryResponse {
+	return j.response.Records
+}
+
+// Next will retrieve the next batch of job information.
+func (j *Jobs) Next() (*Jobs, error) {
+	if j.Done() == true {
+		return nil, errors.New("jobs: there is no more records")
+	}
+	url := j.response.NextRecordsURL
+	if strings.HasPrefix(url, "/") {
+		url = j.session.InstanceURL() + url
+	}
+	request, err := j.request(url)
+	if err != nil {
+		return nil, err
+	}
+	response, err := j.do(request)
+	if err != nil {
+		return nil, err
+	}
+	return &Jobs{
+		session:  j.session,
+		response: response,
+	}, nil
+}
+func (j *Jobs) request(url string) (*http.Request, error) {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Accept", "application/json")
+	j.session.AuthorizationHeader(request)
+	return request, nil
+}
+func (j *Jobs) do(request *http.Request) (jobResponse, error) {
+	response, err := j.session.Client().Do(request)
+	if err != nil {
+		return jobResponse{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return jobResponse{}, sfdc.HandleError(response)
+	}
+
+	var value jobResponse
+	err = json.NewDecoder(response.Body).Decode(&value)
+	if err != nil {
+		return jobResponse{}, err
+	}
+	return value, nil
+}
@@ -46,6 +46,15 @@ func (r *Resource) CreateJob(options QueryOptions) (*QueryJob, error) {
 	return job, nil
 }
 
+// CreateQueryAllJob will create a new bulk 2.0 job for the query that includes the
+// deleted and archived records.  The operation of the options is set to queryAll and
+// the other options that are not set are defaulted.
+func (r *Resource) CreateQueryAllJob(query string, options QueryOptions) (*QueryJob, error) {
+	options.Query = query
+	options.Operation = QueryAll
+	return r.CreateJob(options)
+}
+
 // GetJob will retrieve an existing bulk 2.0 job using the provided ID.
 func (r *Resource) GetJob(id string) (*QueryJob, error) {
 	job := &QueryJob{
@@ -60,11 +69,11 @@ func (r *Resource) GetJob(id string) (*QueryJob, error) {
 	return job, nil
 }
 
-// // AllJobs will retrieve all of the bulk 2.0 jobs.
-// func (r *Resource) AllJobs(parameters Parameters) (*Jobs, error) {
-// 	jobs, err := newJobs(r.session, parameters)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	return jobs, nil
-// }
+// AllJobs will retrieve all of the bulk 2.0 query jobs that match the parameters.
+func (r *Resource) AllJobs(parameters JobListParams) (*Jobs, error) {
+	jobs, err := newJobs(r.session, parameters)
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
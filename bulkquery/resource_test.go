@@ -0,0 +1,281 @@
+package bulkquery
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResource_CreateJob_Body(t *testing.T) {
+	var body map[string]string
+	r := &Resource{
+		session: &mockSessionFormatter{
+			url: "https://test.salesforce.com",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				if req.URL.String() != "https://test.salesforce.com/jobs/query" || req.Method != http.MethodPost {
+					return &http.Response{
+						StatusCode: 500,
+						Status:     "Invalid Request",
+						Body:       ioutil.NopCloser(strings.NewReader(req.Method + " " + req.URL.String())),
+						Header:     make(http.Header),
+					}
+				}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return &http.Response{
+						StatusCode: 500,
+						Status:     "Invalid Body",
+						Body:       ioutil.NopCloser(strings.NewReader(err.Error())),
+						Header:     make(http.Header),
+					}
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Status:     "OK",
+					Body:       ioutil.NopCloser(strings.NewReader(`{"id":"1234","jobType":"V2Query","state":"UploadComplete"}`)),
+					Header:     make(http.Header),
+				}
+			}),
+		},
+	}
+
+	job, err := r.CreateJob(QueryOptions{
+		Query: " SELECT Id FROM Account ",
+	})
+	if err != nil {
+		t.Fatalf("Resource.CreateJob() error = %v", err)
+	}
+	want := map[string]string{
+		"columnDelimiter": "COMMA",
+		"contentType":     "CSV",
+		"lineEnding":      "LF",
+		"query":           "SELECT Id FROM Account",
+		"operation":       "query",
+		"jobType":         "V2Query",
+	}
+	if reflect.DeepEqual(body, want) == false {
+		t.Errorf("Resource.CreateJob() body = %v, want %v", body, want)
+	}
+	if job.QueryResponse.JobType != V2Query {
+		t.Errorf("Resource.CreateJob() job type = %v, want %v", job.QueryResponse.JobType, V2Query)
+	}
+}
+
+func TestResource_CreateQueryAllJob(t *testing.T) {
+	var body map[string]string
+	r := &Resource{
+		session: &mockSessionFormatter{
+			url: "https://test.salesforce.com",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return &http.Response{
+						StatusCode: 500,
+						Status:     "Invalid Body",
+						Body:       ioutil.NopCloser(strings.NewReader(err.Error())),
+						Header:     make(http.Header),
+					}
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Status:     "OK",
+					Body:       ioutil.NopCloser(strings.NewReader(`{"id":"1234","operation":"queryAll","state":"UploadComplete"}`)),
+					Header:     make(http.Header),
+				}
+			}),
+		},
+	}
+
+	job, err := r.CreateQueryAllJob("SELECT Id FROM Account", QueryOptions{
+		Query:           "SELECT Name FROM Contact",
+		Operation:       Query,
+		ColumnDelimiter: Pipe,
+	})
+	if err != nil {
+		t.Fatalf("Resource.CreateQueryAllJob() error = %v", err)
+	}
+	want := map[string]string{
+		"columnDelimiter": "PIPE",
+		"contentType":     "CSV",
+		"lineEnding":      "LF",
+		"query":           "SELECT Id FROM Account",
+		"operation":       "queryAll",
+		"jobType":         "V2Query",
+	}
+	if reflect.DeepEqual(body, want) == false {
+		t.Errorf("Resource.CreateQueryAllJob() body = %v, want %v", body, want)
+	}
+	if job.QueryResponse.Operation != QueryAll {
+		t.Errorf("Resource.CreateQueryAllJob() operation = %v, want %v", job.QueryResponse.Operation, QueryAll)
+	}
+}
+
+func TestResource_AllJobs(t *testing.T) {
+	chunking := true
+	tests := []struct {
+		name       string
+		parameters JobListParams
+		wantQuery  string
+		want       []QueryResponse
+		wantDone   bool
+		wantErr    bool
+	}{
+		{
+			name:       "No Filters",
+			parameters: JobListParams{},
+			wantQuery:  "",
+			want: []QueryResponse{
+				{ID: "1234", JobType: V2Query},
+			},
+			wantDone: true,
+			wantErr:  false,
+		},
+		{
+			name: "Filters",
+			parameters: JobListParams{
+				IsPkChunkingEnabled: &chunking,
+				JobType:             V2Query,
+				ConcurrencyMode:     "Parallel",
+				QueryLocator:        "01gD0000002HU6KIAW-2000",
+			},
+			wantQuery: "concurrencyMode=Parallel&isPkChunkingEnabled=true&jobType=V2Query&queryLocator=01gD0000002HU6KIAW-2000",
+			want: []QueryResponse{
+				{ID: "1234", JobType: V2Query},
+			},
+			wantDone: true,
+			wantErr:  false,
+		},
+		{
+			name:       "Error Response",
+			parameters: JobListParams{JobType: Classic},
+			wantQuery:  "",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resource{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.Path != "/jobs/query" || req.URL.RawQuery != tt.wantQuery {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid Request",
+								Body:       ioutil.NopCloser(strings.NewReader(req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "OK",
+							Body:       ioutil.NopCloser(strings.NewReader(`{"done":true,"records":[{"id":"1234","jobType":"V2Query"}]}`)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			}
+
+			got, err := r.AllJobs(tt.parameters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.AllJobs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if reflect.DeepEqual(got.Records(), tt.want) == false {
+				t.Errorf("Resource.AllJobs() records = %+v, want %+v", got.Records(), tt.want)
+			}
+			if got.Done() != tt.wantDone {
+				t.Errorf("Resource.AllJobs() done = %v, want %v", got.Done(), tt.wantDone)
+			}
+		})
+	}
+}
+
+func TestJobs_Next(t *testing.T) {
+	tests := []struct {
+		name     string
+		response jobResponse
+		want     []QueryResponse
+		wantErr  bool
+	}{
+		{
+			name: "Relative URL",
+			response: jobResponse{
+				NextRecordsURL: "/jobs/query?queryLocator=01gD0000002HU6KIAW-2000",
+			},
+			want: []QueryResponse{
+				{ID: "5678", JobType: V2Query},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Absolute URL",
+			response: jobResponse{
+				NextRecordsURL: "https://test.salesforce.com/jobs/query?queryLocator=01gD0000002HU6KIAW-2000",
+			},
+			want: []QueryResponse{
+				{ID: "5678", JobType: V2Query},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Done",
+			response: jobResponse{
+				Done: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error Response",
+			response: jobResponse{
+				NextRecordsURL: "/jobs/query?queryLocator=missing",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := &Jobs{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.String() != "https://test.salesforce.com/jobs/query?queryLocator=01gD0000002HU6KIAW-2000" {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid Request",
+								Body:       ioutil.NopCloser(strings.NewReader(req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "OK",
+							Body:       ioutil.NopCloser(strings.NewReader(`{"done":true,"records":[{"id":"5678","jobType":"V2Query"}]}`)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				response: tt.response,
+			}
+
+			got, err := jobs.Next()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Jobs.Next() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if reflect.DeepEqual(got.Records(), tt.want) == false {
+				t.Errorf("Jobs.Next() records = %+v, want %+v", got.Records(), tt.want)
+			}
+			if got.Done() == false {
+				t.Errorf("Jobs.Next() done = %v, want true", got.Done())
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package bulkquery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/enrique-esquivel/go-sfdc/internal/csvstruct"
+)
+
+// timeLayouts are the layouts used to parse date and date time columns.
+var timeLayouts = []string{
+	"2006-01-02T15:04:05.000Z0700",
+	time.RFC3339Nano,
+	"2006-01-02",
+}
+
+// UnmarshalResults will export every page of the job results and unmarshal the
+// records into dest, which is a pointer to a slice of structs or struct pointers.
+// The pageSize is the number of records in each page, see ExportInfo.  The context
+// is checked before each page is exported.
+//
+// The column of a struct field is the name in the csv tag, or the field name when
+// there is no tag.  A field with the tag "-" is skipped.  The fields of a nested
+// struct are the columns prefixed with the nested struct's column and a dot, like
+// Account.Name for the Name field of the Account relationship.
+//
+// The columns are converted to the field types, which can be strings, integers,
+// floats, bools, time.Time and pointers to them.  Empty columns are the zero value,
+// or nil for pointers, and a nested struct pointer is nil when all of its columns
+// are empty, like a null relationship.  Date times are parsed as ISO 8601 and dates
+// as YYYY-MM-DD.  The columns of the results that are not fields are skipped.  A
+// struct that nests its own type, like a parent record of the same type, is an error.
+func (j *QueryJob) UnmarshalResults(ctx context.Context, dest interface{}, pageSize int) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Slice {
+		return errors.New("bulk query unmarshal: destination must be a pointer to a slice of structs")
+	}
+	slice := value.Elem()
+	elemType := slice.Type().Elem()
+	recordType := elemType
+	if recordType.Kind() == reflect.Ptr {
+		recordType = recordType.Elem()
+	}
+	if recordType.Kind() != reflect.Struct {
+		return errors.New("bulk query unmarshal: destination must be a pointer to a slice of structs")
+	}
+
+	columns, err := csvstruct.Columns(recordType)
+	if err != nil {
+		return fmt.Errorf("bulk query unmarshal: %w", err)
+	}
+
+	records, err := j.AllRecords(ctx, pageSize)
+	if err != nil {
+		return err
+	}
+
+	results := reflect.MakeSlice(slice.Type(), 0, len(records))
+	for idx, record := range records {
+		result := reflect.New(recordType)
+		for _, column := range columns {
+			text, ok := record[column.Name]
+			if ok == false || text == "" {
+				continue
+			}
+			if err := setValue(column.Field(result.Elem()), text); err != nil {
+				return fmt.Errorf("bulk query unmarshal: record %d column %s: %w", idx, column.Name, err)
+			}
+		}
+		if elemType.Kind() == reflect.Ptr {
+			results = reflect.Append(results, result)
+		} else {
+			results = reflect.Append(results, result.Elem())
+		}
+	}
+	slice.Set(results)
+	return nil
+}
+
+func setValue(field reflect.Value, text string) error {
+	if field.Kind() == reflect.Ptr {
+		if text == "" {
+			field.Set(reflect.Zero(field.Type()))
+			return nil
+		}
+		value := reflect.New(field.Type().Elem())
+		if err := setValue(value.Elem(), text); err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+	}
+	if text == "" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	if field.Type() == csvstruct.TimeType {
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, text); err == nil {
+				field.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not a date or date time", text)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			return fmt.Errorf("%q is not a bool", text)
+		}
+		field.SetBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(text, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", text)
+		}
+		field.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(text, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not an unsigned integer", text)
+		}
+		field.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(text, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a number", text)
+		}
+		field.SetFloat(value)
+	default:
+		return fmt.Errorf("%s fields are not supported", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package bulkquery
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type unmarshalOwner struct {
+	Name string
+}
+
+type unmarshalAccount struct {
+	ID        string          `csv:"Id"`
+	Name      *string         `csv:"Name"`
+	Employees int             `csv:"NumberOfEmployees"`
+	Revenue   float64         `csv:"AnnualRevenue"`
+	Active    bool            `csv:"Active__c"`
+	Created   time.Time       `csv:"CreatedDate"`
+	Closed    *time.Time      `csv:"ClosedDate__c"`
+	Owner     unmarshalOwner  `csv:"Owner"`
+	Parent    *unmarshalOwner `csv:"Parent"`
+	Ignored   string          `csv:"-"`
+}
+
+type unmarshalParent struct {
+	Name   string
+	Parent *unmarshalParent
+}
+
+func TestQueryJob_UnmarshalResults(t *testing.T) {
+	name := "Acme"
+	closed := time.Date(2020, time.March, 4, 0, 0, 0, 0, time.UTC)
+	created, _ := time.Parse("2006-01-02T15:04:05.000Z0700", "2020-01-02T03:04:05.000+0000")
+	tests := []struct {
+		name    string
+		results string
+		dest    func() interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "Nested, Pointer and Time",
+			results: "Id,Name,NumberOfEmployees,AnnualRevenue,Active__c,CreatedDate,ClosedDate__c,Owner.Name,Parent.Name\n" +
+				"001,Acme,10,1.5,true,2020-01-02T03:04:05.000+0000,2020-03-04,Jane,Parent Co\n",
+			dest: func() interface{} { return &[]unmarshalAccount{} },
+			want: &[]unmarshalAccount{
+				{
+					ID:        "001",
+					Name:      &name,
+					Employees: 10,
+					Revenue:   1.5,
+					Active:    true,
+					Created:   created,
+					Closed:    &closed,
+					Owner:     unmarshalOwner{Name: "Jane"},
+					Parent:    &unmarshalOwner{Name: "Parent Co"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Null Columns",
+			results: "Id,Name,NumberOfEmployees,ClosedDate__c,Parent.Name\n001,,,,\n",
+			dest:    func() interface{} { return &[]*unmarshalAccount{} },
+			want: &[]*unmarshalAccount{
+				{
+					ID: "001",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Unknown Column",
+			results: "Id,Website,Ignored\n001,example.com,value\n",
+			dest:    func() interface{} { return &[]unmarshalAccount{} },
+			want: &[]unmarshalAccount{
+				{
+					ID: "001",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "No Records",
+			results: "Id\n",
+			dest:    func() interface{} { return &[]unmarshalAccount{} },
+			want:    &[]unmarshalAccount{},
+			wantErr: false,
+		},
+		{
+			name:    "Bad Integer",
+			results: "Id,NumberOfEmployees\n001,many\n",
+			dest:    func() interface{} { return &[]unmarshalAccount{} },
+			wantErr: true,
+		},
+		{
+			name:    "Bad Time",
+			results: "Id,CreatedDate\n001,yesterday\n",
+			dest:    func() interface{} { return &[]unmarshalAccount{} },
+			wantErr: true,
+		},
+		{
+			name:    "Self Reference",
+			results: "Name\nAcme\n",
+			dest:    func() interface{} { return &[]unmarshalParent{} },
+			wantErr: true,
+		},
+		{
+			name:    "Not a Pointer",
+			results: "Id\n001\n",
+			dest:    func() interface{} { return []unmarshalAccount{} },
+			wantErr: true,
+		},
+		{
+			name:    "Not Structs",
+			results: "Id\n001\n",
+			dest:    func() interface{} { return &[]string{} },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &QueryJob{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						header := make(http.Header)
+						header.Set("Sforce-Locator", "null")
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "OK",
+							Body:       ioutil.NopCloser(strings.NewReader(tt.results)),
+							Header:     header,
+						}
+					}),
+				},
+				QueryResponse: QueryResponse{
+					ID:              "1234",
+					ColumnDelimiter: Comma,
+				},
+			}
+			dest := tt.dest()
+			err := job.UnmarshalResults(context.Background(), dest, 0)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("QueryJob.UnmarshalResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == false && reflect.DeepEqual(dest, tt.want) == false {
+				t.Errorf("QueryJob.UnmarshalResults() = %+v, want %+v", dest, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryJob_UnmarshalResults_Canceled(t *testing.T) {
+	job := &QueryJob{
+		session: &mockSessionFormatter{
+			url: "https://test.salesforce.com",
+		},
+		QueryResponse: QueryResponse{
+			ID:              "1234",
+			ColumnDelimiter: Comma,
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var dest []unmarshalAccount
+	if err := job.UnmarshalResults(ctx, &dest, 0); err != context.Canceled {
+		t.Errorf("QueryJob.UnmarshalResults() error = %v, want %v", err, context.Canceled)
+	}
+}
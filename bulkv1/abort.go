@@ -0,0 +1,54 @@
+package bulkv1
+
+import (
+	"context"
+	"time"
+)
+
+// AbortAndWait will abort the job and then poll the batches at the interval until
+// all of them reach a terminal state, NotProcessed, Completed or Failed.  Batches
+// that were in progress when the job was aborted are still processed to completion.
+// The final batch information is returned.  If the context is done before the
+// batches are finished, the context error is returned with the last batch information.
+func (j *Job) AbortAndWait(ctx context.Context, interval time.Duration) ([]BatchInfo, error) {
+	if _, err := j.Abort(); err != nil {
+		return nil, err
+	}
+
+	for {
+		batches, err := j.Batches()
+		if err != nil {
+			return nil, err
+		}
+		if batchesTerminal(batches) {
+			return batches, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return batches, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Terminal returns true when the batch will not be processed any further.
+func (s BatchState) Terminal() bool {
+	switch s {
+	case NotProcessed, Completed, BatchFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+func batchesTerminal(batches []BatchInfo) bool {
+	for _, batch := range batches {
+		if batch.State.Terminal() == false {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package bulkv1
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJob_AbortAndWait(t *testing.T) {
+	const inProgress = `{"batchInfo":[{"id":"751","jobId":"750","state":"Completed"},{"id":"752","jobId":"750","state":"InProgress"}]}`
+	const terminal = `{"batchInfo":[{"id":"751","jobId":"750","state":"Completed"},{"id":"752","jobId":"750","state":"NotProcessed"}]}`
+
+	tests := []struct {
+		name        string
+		abortStatus int
+		batches     []string
+		canceled    bool
+		want        []BatchInfo
+		wantPolls   int
+		wantErr     bool
+	}{
+		{
+			name:        "Terminal",
+			abortStatus: http.StatusOK,
+			batches:     []string{terminal},
+			want: []BatchInfo{
+				{ID: "751", JobID: "750", State: Completed},
+				{ID: "752", JobID: "750", State: NotProcessed},
+			},
+			wantPolls: 1,
+			wantErr:   false,
+		},
+		{
+			name:        "Polled Until Terminal",
+			abortStatus: http.StatusOK,
+			batches:     []string{inProgress, inProgress, terminal},
+			want: []BatchInfo{
+				{ID: "751", JobID: "750", State: Completed},
+				{ID: "752", JobID: "750", State: NotProcessed},
+			},
+			wantPolls: 3,
+			wantErr:   false,
+		},
+		{
+			name:        "Canceled",
+			abortStatus: http.StatusOK,
+			batches:     []string{inProgress},
+			canceled:    true,
+			want: []BatchInfo{
+				{ID: "751", JobID: "750", State: Completed},
+				{ID: "752", JobID: "750", State: InProgress},
+			},
+			wantPolls: 1,
+			wantErr:   true,
+		},
+		{
+			name:        "Abort Error",
+			abortStatus: http.StatusBadRequest,
+			wantPolls:   0,
+			wantErr:     true,
+		},
+		{
+			name:        "Batches Error",
+			abortStatus: http.StatusOK,
+			wantPolls:   1,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			job := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						switch {
+						case req.Method == http.MethodPost && req.URL.String() == "https://test.salesforce.com/services/async/v42.0/job/750":
+							body, _ := ioutil.ReadAll(req.Body)
+							if tt.abortStatus != http.StatusOK || string(body) != `{"state":"Aborted"}` {
+								return &http.Response{
+									StatusCode: http.StatusBadRequest,
+									Status:     "Bad Request",
+									Body:       ioutil.NopCloser(strings.NewReader(string(body))),
+									Header:     make(http.Header),
+								}
+							}
+							return &http.Response{
+								StatusCode: http.StatusOK,
+								Status:     "OK",
+								Body:       ioutil.NopCloser(strings.NewReader(`{"id":"750","state":"Aborted"}`)),
+								Header:     make(http.Header),
+							}
+						case req.Method == http.MethodGet && req.URL.String() == "https://test.salesforce.com/services/async/v42.0/job/750/batch":
+							polls++
+							if polls > len(tt.batches) {
+								return &http.Response{
+									StatusCode: http.StatusInternalServerError,
+									Status:     "Internal Server Error",
+									Body:       ioutil.NopCloser(strings.NewReader("no more batches")),
+									Header:     make(http.Header),
+								}
+							}
+							return &http.Response{
+								StatusCode: http.StatusOK,
+								Status:     "OK",
+								Body:       ioutil.NopCloser(strings.NewReader(tt.batches[polls-1])),
+								Header:     make(http.Header),
+							}
+						default:
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid Request",
+								Body:       ioutil.NopCloser(strings.NewReader(req.Method + " " + req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+					}),
+				},
+				Response: JobInfo{
+					ID: "750",
+				},
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.canceled {
+				cancel()
+			}
+
+			got, err := job.AbortAndWait(ctx, time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.AbortAndWait() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if reflect.DeepEqual(got, tt.want) == false {
+				t.Errorf("Job.AbortAndWait() = %+v, want %+v", got, tt.want)
+			}
+			if polls != tt.wantPolls {
+				t.Errorf("Job.AbortAndWait() polls = %d, want %d", polls, tt.wantPolls)
+			}
+		})
+	}
+}
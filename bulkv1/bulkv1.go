@@ -0,0 +1,60 @@
+package bulkv1
+
+import (
+	"github.com/enrique-esquivel/go-sfdc/session"
+	"github.com/pkg/errors"
+)
+
+// Resource is the structure that can be used to create bulk 1.0 jobs.
+type Resource struct {
+	session session.AsyncServiceFormatter
+}
+
+// NewResource creates a new bulk 1.0 resource.  If the session is nil
+// an error will be returned.
+func NewResource(session session.AsyncServiceFormatter) (*Resource, error) {
+	if session == nil {
+		return nil, errors.New("bulk v1: session can not be nil")
+	}
+
+	err := session.Refresh()
+	if err != nil {
+		return nil, errors.Wrap(err, "session refresh")
+	}
+
+	return &Resource{
+		session: session,
+	}, nil
+}
+
+// CreateJob will create a new bulk 1.0 job from the options that where passed.
+// The Job that is returned can be used to add batches to the Salesforce org.
+func (r *Resource) CreateJob(options Options, header HeaderOptions) (*Job, error) {
+	job := &Job{
+		session: r.session,
+	}
+	if err := job.Create(options, header); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// CreateQueryJob will create a new bulk 1.0 query job.  The all parameter is for
+// querying all records, which include deleted and archived records.
+func (r *Resource) CreateQueryJob(object string, all bool, header HeaderOptions) (*Job, error) {
+	operation := Query
+	if all {
+		operation = QueryAll
+	}
+	options := Options{
+		ContentType: header.ContentType,
+		Object:      object,
+		Operation:   operation,
+	}
+	if options.ContentType == "" {
+		options.ContentType = CSV
+	}
+
+	return r.CreateJob(options, header)
+}
@@ -0,0 +1,99 @@
+package bulkv1
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestResource_CreateQueryJob(t *testing.T) {
+	tests := []struct {
+		name     string
+		all      bool
+		header   HeaderOptions
+		status   int
+		wantBody Options
+		wantErr  bool
+	}{
+		{
+			name:   "Query",
+			all:    false,
+			status: http.StatusOK,
+			wantBody: Options{
+				ContentType: CSV,
+				Object:      "Account",
+				Operation:   Query,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Query All JSON",
+			all:  true,
+			header: HeaderOptions{
+				ContentType: JSON,
+			},
+			status: http.StatusOK,
+			wantBody: Options{
+				ContentType: JSON,
+				Object:      "Account",
+				Operation:   QueryAll,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Error Response",
+			status: http.StatusBadRequest,
+			wantBody: Options{
+				ContentType: CSV,
+				Object:      "Account",
+				Operation:   Query,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body Options
+			r := &Resource{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.String() != "https://test.salesforce.com/services/async/v42.0/job" || req.Method != http.MethodPost {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid Request",
+								Body:       ioutil.NopCloser(strings.NewReader(req.Method + " " + req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+						json.NewDecoder(req.Body).Decode(&body)
+						resp := `{"id":"750","object":"Account","operation":"query","state":"Open"}`
+						if tt.status != http.StatusOK {
+							resp = `[{"errorCode":"InvalidJob","message":"Unable to find object: Account"}]`
+						}
+						return &http.Response{
+							StatusCode: tt.status,
+							Status:     http.StatusText(tt.status),
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			}
+
+			got, err := r.CreateQueryJob("Account", tt.all, tt.header)
+			if body != tt.wantBody {
+				t.Errorf("Resource.CreateQueryJob() body = %+v, want %+v", body, tt.wantBody)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.CreateQueryJob() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == false && got.Response.ID != "750" {
+				t.Errorf("Resource.CreateQueryJob() ID = %v, want %v", got.Response.ID, "750")
+			}
+		})
+	}
+}
@@ -2,9 +2,12 @@ package bulkv1
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 
@@ -12,7 +15,9 @@ import (
 	"github.com/enrique-esquivel/go-sfdc/session"
 )
 
-const bulkEndpoint string = "job"
+// bulkEndpoint is the job resource, which is relative to the async service URL,
+// like /services/async/v45.0/job.
+const bulkEndpoint string = "/job"
 
 // ContentType is the format of the data being processed.
 type ContentType string
@@ -51,6 +56,10 @@ const (
 	Update Operation = "update"
 	// Upsert is the object operation for upserting records.
 	Upsert Operation = "upsert"
+	// Query is the object operation for querying records.
+	Query Operation = "query"
+	// QueryAll is the object operation for querying records, including deleted and archived records.
+	QueryAll Operation = "queryAll"
 )
 
 // State is the current state of processing for the job.
@@ -67,25 +76,26 @@ const (
 	Failed State = "Failed"
 )
 
-// State is the current state of batch processing.
+// BatchState is the current state of batch processing, which is separate from the
+// state of the job.
 type BatchState string
 
 const (
 	// Processing of the batch hasn’t started yet. If the job associated with this batch is aborted,
 	// the batch isn’t processed and its state is set to NotProcessed.
-	Queue BatchState = "Open"
+	Queue BatchState = "Queued"
 	// The batch is being processed. If the job associated with the batch is aborted,
 	// the batch is still processed to completion. You must close the job associated with the batch so that the batch can finish processing.
 	InProgress BatchState = "InProgress"
 	// The batch has been processed completely, and the result resource is available. The result resource indicates if some records failed.
 	// A batch can be completed even if some or all the records failed. If a subset of records failed, the successful records aren’t rolled back.
-	Completed State = "Completed"
+	Completed BatchState = "Completed"
 	// The batch failed to process the full request due to an unexpected error, such as the request is compressed with an unsupported format,
 	// or an internal server error.
-	BatchFailed State = "Failed"
+	BatchFailed BatchState = "Failed"
 	// The batch won’t be processed. This state is assigned when a job is aborted while the batch is queued. For bulk queries,
 	// if the job has PK chunking enabled, this state is assigned to the original batch that contains the query when the subsequent batches are created
-	NotProcessed State = "NotProcessed"
+	NotProcessed BatchState = "NotProcessed"
 )
 
 type Header string
@@ -109,7 +119,7 @@ const (
 // When a job is created, Salesforce sets the job state to Open.
 
 type JobInfo struct {
-	APIVersion              float32             `json:"apiVersion"`
+	APIVersion              sfdc.APIVersion     `json:"apiVersion"`
 	ApexProcessingTime      int                 `json:"apexProcessingTime"`
 	ApiActiveProcessingTime int                 `json:"apiActiveProcessingTime"`
 	AssignmentRuleId        string              `json:"assignmentRuleId"`
@@ -173,8 +183,11 @@ type Job struct {
 	Response JobInfo
 }
 
+// Create will create the bulk job.  The external ID field name is removed when the
+// operation is not upsert, since Salesforce only uses it to match the records of
+// an upsert.
 func (j *Job) Create(options Options, header HeaderOptions) error {
-	err := j.formatOptions(options, &header)
+	err := j.formatOptions(&options, &header)
 	if err != nil {
 		return err
 	}
@@ -186,7 +199,7 @@ func (j *Job) Create(options Options, header HeaderOptions) error {
 	return nil
 }
 
-func (j *Job) formatOptions(options Options, header *HeaderOptions) error {
+func (j *Job) formatOptions(options *Options, header *HeaderOptions) error {
 	if options.Operation == "" {
 		return errors.New("bulk job: operation is required")
 	}
@@ -198,6 +211,9 @@ func (j *Job) formatOptions(options Options, header *HeaderOptions) error {
 	if options.Object == "" {
 		return errors.New("bulk job: object is required")
 	}
+	if options.Operation != Upsert {
+		options.ExternalIDFieldName = ""
+	}
 	if header.LineEnding == "" {
 		header.LineEnding = Linefeed
 	}
@@ -253,9 +269,25 @@ func (j *Job) response(request *http.Request) (JobInfo, error) {
 	return value, nil
 }
 
+// The limits of a batch's data.
+const (
+	// MaxBatchRecords is the most records in a batch.
+	MaxBatchRecords = 10000
+	// MaxBatchSize is the largest batch data, in bytes.
+	MaxBatchSize = 10000000
+)
+
+// CreateBatch will add a batch with the CSV data to the job.  The data is checked
+// against the batch limits, MaxBatchRecords and MaxBatchSize, before it is sent.
+// The records are only counted when the job's content type is CSV.
 func (j *Job) CreateBatch(body io.Reader) (BatchInfo, error) {
+	data, err := readBatch(body, j.Response.ContentType)
+	if err != nil {
+		return BatchInfo{}, err
+	}
+
 	url := j.session.AsyncServiceURL() + bulkEndpoint + "/" + j.Response.ID + "/batch"
-	request, err := http.NewRequest(http.MethodPost, url, body)
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
 	if err != nil {
 		return BatchInfo{}, err
 	}
@@ -280,6 +312,94 @@ func (j *Job) CreateBatch(body io.Reader) (BatchInfo, error) {
 	return value, nil
 }
 
+func readBatch(body io.Reader, contentType ContentType) ([]byte, error) {
+	if body == nil {
+		return nil, errors.New("bulk job: batch data is required")
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(body, MaxBatchSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxBatchSize {
+		return nil, fmt.Errorf("bulk job: batch data is more than %d bytes", MaxBatchSize)
+	}
+	if contentType != CSV && contentType != "" {
+		return data, nil
+	}
+	if records := batchRecords(data); records > MaxBatchRecords {
+		return nil, fmt.Errorf("bulk job: batch has %d records, which is more than %d", records, MaxBatchRecords)
+	}
+	return data, nil
+}
+
+// batchRecords counts the records of the CSV data, without the header.  Data that
+// can not be read as CSV is counted up to the error and left for Salesforce to
+// reject.
+func batchRecords(data []byte) int {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+
+	rows := 0
+	for {
+		if _, err := reader.Read(); err != nil {
+			break
+		}
+		rows++
+	}
+	if rows == 0 {
+		return 0
+	}
+	return rows - 1
+}
+
+// RetryBatch will resubmit the data of a failed batch as a new batch on the
+// same job.  If the body is nil, the data that was originally submitted for
+// the batch is resubmitted.  The job must still be open.
+func (j *Job) RetryBatch(batch BatchInfo, body io.Reader) (BatchInfo, error) {
+	if batch.JobID != "" && batch.JobID != j.Response.ID {
+		return BatchInfo{}, errors.New("bulk job: batch " + batch.ID + " is not part of job " + j.Response.ID)
+	}
+	if batch.State != BatchFailed {
+		return BatchInfo{}, errors.New("bulk job: batch " + batch.ID + " has not failed")
+	}
+	if body != nil {
+		return j.CreateBatch(body)
+	}
+
+	response, err := j.BatchRequest(batch)
+	if err != nil {
+		return BatchInfo{}, err
+	}
+	defer response.Body.Close()
+
+	return j.CreateBatch(response.Body)
+}
+
+// BatchRequest returns the data that was submitted for the batch.  The caller
+// is responsible for closing the response body.
+func (j *Job) BatchRequest(batch BatchInfo) (*http.Response, error) {
+	url := j.session.AsyncServiceURL() + bulkEndpoint + "/" + j.Response.ID + "/batch/" + batch.ID + "/request"
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	j.session.AuthorizationHeader(request)
+
+	response, err := j.session.Client().Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, sfdc.HandleError(response)
+	}
+
+	return response, nil
+}
+
 // Info returns the current job information.
 func (j *Job) BatchInfo(info BatchInfo) (BatchInfo, error) {
 	return j.fetchBatchInfo(j.Response.ID, info.ID)
@@ -306,7 +426,7 @@ func (j *Job) infoResponse(request *http.Request) (BatchInfo, error) {
 	return value, nil
 }
 func (j *Job) fetchBatchInfo(jobId, batchId string) (BatchInfo, error) {
-	url := j.session.ServiceURL() + bulkEndpoint + "/" + jobId + "/batch/" + batchId
+	url := j.session.AsyncServiceURL() + bulkEndpoint + "/" + jobId + "/batch/" + batchId
 	request, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return BatchInfo{}, err
@@ -318,8 +438,10 @@ func (j *Job) fetchBatchInfo(jobId, batchId string) (BatchInfo, error) {
 	return j.infoResponse(request)
 }
 
+// setState changes the state of the job, which the bulk 1.0 API does with a POST
+// to the job rather than the PATCH of the bulk 2.0 API.
 func (j *Job) setState(state State) (JobInfo, error) {
-	url := j.session.ServiceURL() + bulkEndpoint + "/" + j.Response.ID
+	url := j.session.AsyncServiceURL() + bulkEndpoint + "/" + j.Response.ID
 	jobState := struct {
 		State string `json:"state"`
 	}{
@@ -329,7 +451,7 @@ func (j *Job) setState(state State) (JobInfo, error) {
 	if err != nil {
 		return JobInfo{}, err
 	}
-	request, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return JobInfo{}, err
 	}
@@ -352,7 +474,7 @@ func (j *Job) Abort() (JobInfo, error) {
 
 // Delete will delete the current job.
 func (j *Job) Delete() error {
-	url := j.session.ServiceURL() + bulkEndpoint + "/" + j.Response.ID
+	url := j.session.AsyncServiceURL() + bulkEndpoint + "/" + j.Response.ID
 	request, err := http.NewRequest(http.MethodDelete, url, nil)
 	if err != nil {
 		return err
@@ -363,15 +485,16 @@ func (j *Job) Delete() error {
 	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		return errors.New("job error: unable to delete job")
+		return sfdc.HandleError(response)
 	}
 	return nil
 }
 
 func (j *Job) BatchResult(batchInfo BatchInfo) (*http.Response, error) {
-	url := j.session.ServiceURL() + bulkEndpoint + "/" + j.Response.ID + "/batch/" + batchInfo.ID + "/result"
+	url := j.session.AsyncServiceURL() + bulkEndpoint + "/" + j.Response.ID + "/batch/" + batchInfo.ID + "/result"
 	request, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,224 @@
+package bulkv1
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestJob_CreateBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType ContentType
+		body        io.Reader
+		want        BatchInfo
+		wantErr     bool
+	}{
+		{
+			name:        "Passing",
+			contentType: CSV,
+			body:        strings.NewReader("Name\nfirst\nsecond\n"),
+			want: BatchInfo{
+				ID:    "751",
+				JobID: "750",
+				State: Queue,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "Record Limit",
+			contentType: CSV,
+			body:        strings.NewReader("Name\n" + strings.Repeat("name\n", MaxBatchRecords)),
+			want: BatchInfo{
+				ID:    "751",
+				JobID: "750",
+				State: Queue,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "Over Record Limit",
+			contentType: CSV,
+			body:        strings.NewReader("Name\n" + strings.Repeat("name\n", MaxBatchRecords+1)),
+			wantErr:     true,
+		},
+		{
+			name:        "Lines Not Counted For JSON",
+			contentType: JSON,
+			body:        strings.NewReader(strings.Repeat("{}\n", MaxBatchRecords+2)),
+			want: BatchInfo{
+				ID:    "751",
+				JobID: "750",
+				State: Queue,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "Byte Limit",
+			contentType: JSON,
+			body:        strings.NewReader(strings.Repeat("x", MaxBatchSize)),
+			want: BatchInfo{
+				ID:    "751",
+				JobID: "750",
+				State: Queue,
+			},
+			wantErr: false,
+		},
+		{
+			name:        "Over Byte Limit",
+			contentType: JSON,
+			body:        strings.NewReader(strings.Repeat("x", MaxBatchSize+1)),
+			wantErr:     true,
+		},
+		{
+			name:        "No Data",
+			contentType: CSV,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.String() != "https://test.salesforce.com/services/async/v42.0/job/750/batch" {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid Request",
+								Body:       ioutil.NopCloser(strings.NewReader(req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+						return &http.Response{
+							StatusCode: http.StatusCreated,
+							Status:     "Created",
+							Body:       ioutil.NopCloser(strings.NewReader(`{"id":"751","jobId":"750","state":"Queued"}`)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				Response: JobInfo{
+					ID:          "750",
+					ContentType: tt.contentType,
+				},
+			}
+
+			got, err := job.CreateBatch(tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.CreateBatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Job.CreateBatch() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_RetryBatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		batch        BatchInfo
+		body         io.Reader
+		requestFails bool
+		wantData     string
+		want         BatchInfo
+		wantErr      bool
+	}{
+		{
+			name:     "Original Data",
+			batch:    BatchInfo{ID: "751", JobID: "750", State: BatchFailed},
+			wantData: "Name\noriginal\n",
+			want: BatchInfo{
+				ID:    "752",
+				JobID: "750",
+				State: Queue,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "New Data",
+			batch:    BatchInfo{ID: "751", JobID: "750", State: BatchFailed},
+			body:     strings.NewReader("Name\nfixed\n"),
+			wantData: "Name\nfixed\n",
+			want: BatchInfo{
+				ID:    "752",
+				JobID: "750",
+				State: Queue,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Not Failed",
+			batch:   BatchInfo{ID: "751", JobID: "750", State: Completed},
+			wantErr: true,
+		},
+		{
+			name:    "Other Job",
+			batch:   BatchInfo{ID: "751", JobID: "760", State: BatchFailed},
+			wantErr: true,
+		},
+		{
+			name:         "Request Error",
+			batch:        BatchInfo{ID: "751", JobID: "750", State: BatchFailed},
+			requestFails: true,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data string
+			job := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						switch {
+						case req.Method == http.MethodGet && req.URL.String() == "https://test.salesforce.com/services/async/v42.0/job/750/batch/751/request" && tt.requestFails == false:
+							return &http.Response{
+								StatusCode: http.StatusOK,
+								Status:     "OK",
+								Body:       ioutil.NopCloser(strings.NewReader("Name\noriginal\n")),
+								Header:     make(http.Header),
+							}
+						case req.Method == http.MethodPost && req.URL.String() == "https://test.salesforce.com/services/async/v42.0/job/750/batch":
+							body, _ := ioutil.ReadAll(req.Body)
+							data = string(body)
+							return &http.Response{
+								StatusCode: http.StatusCreated,
+								Status:     "Created",
+								Body:       ioutil.NopCloser(strings.NewReader(`{"id":"752","jobId":"750","state":"Queued"}`)),
+								Header:     make(http.Header),
+							}
+						default:
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid Request",
+								Body:       ioutil.NopCloser(strings.NewReader(req.Method + " " + req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+					}),
+				},
+				Response: JobInfo{
+					ID:          "750",
+					ContentType: CSV,
+				},
+			}
+
+			got, err := job.RetryBatch(tt.batch, tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.RetryBatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Job.RetryBatch() = %+v, want %+v", got, tt.want)
+			}
+			if data != tt.wantData {
+				t.Errorf("Job.RetryBatch() data = %q, want %q", data, tt.wantData)
+			}
+		})
+	}
+}
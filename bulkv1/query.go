@@ -0,0 +1,111 @@
+package bulkv1
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/enrique-esquivel/go-sfdc"
+)
+
+type batchInfoList struct {
+	BatchInfo []BatchInfo `json:"batchInfo"`
+}
+
+type resultList struct {
+	Results []string `xml:"result"`
+}
+
+// AddQuery will submit the SOQL statement as a batch of the query job.
+func (j *Job) AddQuery(soql string) (BatchInfo, error) {
+	if j.Response.Operation != Query && j.Response.Operation != QueryAll {
+		return BatchInfo{}, errors.New("bulk job: query batches can only be added to query jobs")
+	}
+	if soql == "" {
+		return BatchInfo{}, errors.New("bulk job: query is required")
+	}
+
+	return j.CreateBatch(strings.NewReader(soql))
+}
+
+// Batches returns the information of all of the batches of the job.  When PK chunking
+// is enabled, the original query batch is set to NotProcessed and a batch is
+// created for each chunk.
+func (j *Job) Batches() ([]BatchInfo, error) {
+	url := j.session.AsyncServiceURL() + bulkEndpoint + "/" + j.Response.ID + "/batch"
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Accept", "application/json")
+	j.session.AuthorizationHeader(request)
+
+	response, err := j.session.Client().Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, sfdc.HandleError(response)
+	}
+
+	var value batchInfoList
+	err = json.NewDecoder(response.Body).Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+	return value.BatchInfo, nil
+}
+
+// QueryResultIDs returns the result set IDs of a completed query batch.  Each
+// result set can be retrieved with QueryResult.
+func (j *Job) QueryResultIDs(batchInfo BatchInfo) ([]string, error) {
+	response, err := j.BatchResult(batchInfo)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	// CSV and XML jobs return the result list as XML, JSON jobs as an array.
+	if strings.Contains(response.Header.Get("Content-Type"), "xml") {
+		var value resultList
+		err = xml.NewDecoder(response.Body).Decode(&value)
+		if err != nil {
+			return nil, err
+		}
+		return value.Results, nil
+	}
+
+	var value []string
+	err = json.NewDecoder(response.Body).Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// QueryResult returns the result set of a query batch.  It is the caller's
+// responsibility to close the response body.
+func (j *Job) QueryResult(batchInfo BatchInfo, resultID string) (*http.Response, error) {
+	url := j.session.AsyncServiceURL() + bulkEndpoint + "/" + j.Response.ID + "/batch/" + batchInfo.ID + "/result/" + resultID
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	j.session.AuthorizationHeader(request)
+
+	response, err := j.session.Client().Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, sfdc.HandleError(response)
+	}
+
+	return response, nil
+}
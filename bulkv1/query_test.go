@@ -0,0 +1,292 @@
+package bulkv1
+
+import (
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJob_AddQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation Operation
+		soql      string
+		want      BatchInfo
+		wantErr   bool
+	}{
+		{
+			name:      "Passing",
+			operation: Query,
+			soql:      "SELECT Id FROM Account",
+			want: BatchInfo{
+				ID:    "751",
+				JobID: "750",
+				State: Queue,
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Query All",
+			operation: QueryAll,
+			soql:      "SELECT Id FROM Account",
+			want: BatchInfo{
+				ID:    "751",
+				JobID: "750",
+				State: Queue,
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Not a Query Job",
+			operation: Insert,
+			soql:      "SELECT Id FROM Account",
+			wantErr:   true,
+		},
+		{
+			name:      "No Query",
+			operation: Query,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						body, _ := ioutil.ReadAll(req.Body)
+						if req.URL.String() != "https://test.salesforce.com/services/async/v42.0/job/750/batch" || string(body) != tt.soql {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid Request",
+								Body:       ioutil.NopCloser(strings.NewReader(req.URL.String() + " " + string(body))),
+								Header:     make(http.Header),
+							}
+						}
+						return &http.Response{
+							StatusCode: http.StatusCreated,
+							Status:     "Created",
+							Body:       ioutil.NopCloser(strings.NewReader(`{"id":"751","jobId":"750","state":"Queued"}`)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				Response: JobInfo{
+					ID:        "750",
+					Operation: tt.operation,
+				},
+			}
+
+			got, err := job.AddQuery(tt.soql)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.AddQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Job.AddQuery() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_Batches(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    []BatchInfo
+		wantErr bool
+	}{
+		{
+			name:   "Passing",
+			status: http.StatusOK,
+			body:   `{"batchInfo":[{"id":"751","jobId":"750","state":"NotProcessed"},{"id":"752","jobId":"750","state":"Completed"}]}`,
+			want: []BatchInfo{
+				{
+					ID:    "751",
+					JobID: "750",
+					State: NotProcessed,
+				},
+				{
+					ID:    "752",
+					JobID: "750",
+					State: Completed,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Error Response",
+			status:  http.StatusBadRequest,
+			body:    `[{"errorCode":"InvalidJob","message":"Invalid job id"}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.String() != "https://test.salesforce.com/services/async/v42.0/job/750/batch" || req.Method != http.MethodGet {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid Request",
+								Body:       ioutil.NopCloser(strings.NewReader(req.Method + " " + req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+						return &http.Response{
+							StatusCode: tt.status,
+							Status:     http.StatusText(tt.status),
+							Body:       ioutil.NopCloser(strings.NewReader(tt.body)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				Response: JobInfo{
+					ID: "750",
+				},
+			}
+
+			got, err := job.Batches()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.Batches() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if reflect.DeepEqual(got, tt.want) == false {
+				t.Errorf("Job.Batches() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_QueryResultIDs(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:        "XML",
+			contentType: "application/xml",
+			body:        `<result-list xmlns="http://www.force.com/2009/06/asyncapi/dataload"><result>752</result><result>753</result></result-list>`,
+			want:        []string{"752", "753"},
+			wantErr:     false,
+		},
+		{
+			name:        "JSON",
+			contentType: "application/json",
+			body:        `["752"]`,
+			want:        []string{"752"},
+			wantErr:     false,
+		},
+		{
+			name:        "Bad JSON",
+			contentType: "application/json",
+			body:        `{`,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						header := make(http.Header)
+						header.Set("Content-Type", tt.contentType)
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "OK",
+							Body:       ioutil.NopCloser(strings.NewReader(tt.body)),
+							Header:     header,
+						}
+					}),
+				},
+				Response: JobInfo{
+					ID: "750",
+				},
+			}
+
+			got, err := job.QueryResultIDs(BatchInfo{ID: "751"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.QueryResultIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if reflect.DeepEqual(got, tt.want) == false {
+				t.Errorf("Job.QueryResultIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_QueryResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Passing",
+			status:  http.StatusOK,
+			body:    "\"Id\"\n\"001\"\n",
+			want:    "\"Id\"\n\"001\"\n",
+			wantErr: false,
+		},
+		{
+			name:    "Error Response",
+			status:  http.StatusNotFound,
+			body:    `[{"errorCode":"InvalidBatch","message":"Records not found for this query"}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.String() != "https://test.salesforce.com/services/async/v42.0/job/750/batch/751/result/752" {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Invalid URL",
+								Body:       ioutil.NopCloser(strings.NewReader(req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+						return &http.Response{
+							StatusCode: tt.status,
+							Status:     http.StatusText(tt.status),
+							Body:       ioutil.NopCloser(strings.NewReader(tt.body)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				Response: JobInfo{
+					ID: "750",
+				},
+			}
+
+			got, err := job.QueryResult(BatchInfo{ID: "751"}, "752")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.QueryResult() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			defer got.Body.Close()
+			body, _ := ioutil.ReadAll(got.Body)
+			if string(body) != tt.want {
+				t.Errorf("Job.QueryResult() = %q, want %q", string(body), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Package client is the entry point to the Salesforce APIs.  The client opens one
+// session, which is refreshed when its token expires, and creates the API resources
+// with it.
+package client
+
+import (
+	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/enrique-esquivel/go-sfdc/bulk"
+	"github.com/enrique-esquivel/go-sfdc/bulkquery"
+	"github.com/enrique-esquivel/go-sfdc/bulkv1"
+	"github.com/enrique-esquivel/go-sfdc/composite"
+	"github.com/enrique-esquivel/go-sfdc/session"
+	"github.com/enrique-esquivel/go-sfdc/sobject"
+	"github.com/enrique-esquivel/go-sfdc/sobject/collections"
+	"github.com/enrique-esquivel/go-sfdc/sobject/tree"
+	"github.com/enrique-esquivel/go-sfdc/soql"
+	"github.com/enrique-esquivel/go-sfdc/sosl"
+	"github.com/enrique-esquivel/go-sfdc/streaming"
+	"github.com/pkg/errors"
+)
+
+// Client vends the Salesforce API resources, which share the client's session.
+type Client struct {
+	session session.AsyncServiceFormatter
+}
+
+// New opens a session with the configuration and returns the client that uses it.
+func New(config sfdc.Configuration) (*Client, error) {
+	s, err := session.Open(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		session: s,
+	}, nil
+}
+
+// NewWithSession returns the client that uses an existing session.  If the session
+// is nil an error will be returned.
+func NewWithSession(session session.AsyncServiceFormatter) (*Client, error) {
+	if session == nil {
+		return nil, errors.New("client: session can not be nil")
+	}
+	return &Client{
+		session: session,
+	}, nil
+}
+
+// Session returns the session that is shared by the resources.
+func (c *Client) Session() session.AsyncServiceFormatter {
+	return c.session
+}
+
+// SOQL returns the SOQL query resource.
+func (c *Client) SOQL() (*soql.Resource, error) {
+	return soql.NewResource(c.session)
+}
+
+// SOSL returns the SOSL search resource.
+func (c *Client) SOSL() (*sosl.Resource, error) {
+	return sosl.NewResource(c.session)
+}
+
+// SObjects returns the SObject resources.
+func (c *Client) SObjects() (*sobject.Resources, error) {
+	return sobject.NewResources(c.session)
+}
+
+// Collections returns the SObject collections resource.
+func (c *Client) Collections() (*collections.Resource, error) {
+	return collections.NewResources(c.session)
+}
+
+// Tree returns the SObject tree resource.
+func (c *Client) Tree() (*tree.Resource, error) {
+	return tree.NewResource(c.session)
+}
+
+// Composite returns the composite resource.
+func (c *Client) Composite() (*composite.Resource, error) {
+	return composite.NewResource(c.session)
+}
+
+// BulkIngest returns the bulk 2.0 ingest resource.
+func (c *Client) BulkIngest() (*bulk.Resource, error) {
+	return bulk.NewResource(c.session)
+}
+
+// BulkQuery returns the bulk 2.0 query resource.
+func (c *Client) BulkQuery() (*bulkquery.Resource, error) {
+	return bulkquery.NewResource(c.session)
+}
+
+// BulkV1 returns the bulk 1.0 resource.
+func (c *Client) BulkV1() (*bulkv1.Resource, error) {
+	return bulkv1.NewResource(c.session)
+}
+
+// Streaming returns the streaming resource.
+func (c *Client) Streaming() (*streaming.Resource, error) {
+	return streaming.NewResource(c.session)
+}
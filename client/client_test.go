@@ -0,0 +1,54 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/enrique-esquivel/go-sfdc/session/sessiontest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(sfdc.Configuration{})
+	assert.Error(t, err)
+
+	_, err = NewWithSession(nil)
+	assert.Error(t, err)
+}
+
+func TestClient_Resources(t *testing.T) {
+	fake := sessiontest.NewSession(http.NotFoundHandler())
+	defer fake.Close()
+
+	client, err := NewWithSession(fake)
+	require.NoError(t, err)
+	assert.Equal(t, fake, client.Session())
+
+	resources := map[string]func() (interface{}, error){
+		"soql":        func() (interface{}, error) { return client.SOQL() },
+		"sosl":        func() (interface{}, error) { return client.SOSL() },
+		"sobjects":    func() (interface{}, error) { return client.SObjects() },
+		"collections": func() (interface{}, error) { return client.Collections() },
+		"tree":        func() (interface{}, error) { return client.Tree() },
+		"composite":   func() (interface{}, error) { return client.Composite() },
+		"bulk_ingest": func() (interface{}, error) { return client.BulkIngest() },
+		"bulk_query":  func() (interface{}, error) { return client.BulkQuery() },
+		"bulk_v1":     func() (interface{}, error) { return client.BulkV1() },
+		"streaming":   func() (interface{}, error) { return client.Streaming() },
+	}
+	for name, resource := range resources {
+		t.Run(name, func(t *testing.T) {
+			fake.RefreshErr = nil
+			got, err := resource()
+			require.NoError(t, err)
+			assert.NotNil(t, got)
+
+			fake.RefreshErr = errors.New("expired")
+			_, err = resource()
+			assert.Error(t, err)
+		})
+	}
+}
@@ -66,7 +66,9 @@ func NewResource(session session.ServiceFormatter) (*Resource, error) {
 
 // Retrieve will retrieve the responses to a composite batch requests.  The
 // order of the array is the order in which the subrequests are
-// placed in the composite batch body.
+// placed in the composite batch body.  The composite batch does not honor
+// all or none; when haltOnError is true, the remaining subrequests are not
+// processed after one fails, but the ones that succeeded are not rolled back.
 func (r *Resource) Retrieve(haltOnError bool, requesters []Subrequester) (Value, error) {
 	if requesters == nil {
 		return Value{}, errors.New("composite subrequests: requesters can not nil")
@@ -36,6 +36,73 @@ type Subvalue struct {
 	ReferenceID    string            `json:"referenceId"`
 }
 
+// HasErrors returns true if any of the subrequests failed.
+func (v Value) HasErrors() bool {
+	for _, subvalue := range v.Response {
+		if subvalue.Success() == false {
+			return true
+		}
+	}
+	return false
+}
+
+// Subvalue returns the subresponse for the reference id.
+func (v Value) Subvalue(referenceID string) (Subvalue, bool) {
+	for _, subvalue := range v.Response {
+		if subvalue.ReferenceID == referenceID {
+			return subvalue, true
+		}
+	}
+	return Subvalue{}, false
+}
+
+// Failed returns the subresponses of the subrequests that failed.
+func (v Value) Failed() []Subvalue {
+	var failed []Subvalue
+	for _, subvalue := range v.Response {
+		if subvalue.Success() == false {
+			failed = append(failed, subvalue)
+		}
+	}
+	return failed
+}
+
+// Success returns true if the subrequest did not fail.  A not modified
+// status is a success.
+func (s Subvalue) Success() bool {
+	return s.HTTPStatusCode < http.StatusBadRequest
+}
+
+// Errors returns the errors of a failed subrequest.  When the composite
+// request is all or none, the subrequests that were rolled back will
+// have the PROCESSING_HALTED error.
+func (s Subvalue) Errors() []sfdc.Error {
+	if s.Success() {
+		return nil
+	}
+	body, err := json.Marshal(s.Body)
+	if err != nil {
+		return nil
+	}
+	var errs []sfdc.Error
+	if err := json.Unmarshal(body, &errs); err != nil {
+		return nil
+	}
+	return errs
+}
+
+// Err returns the errors of a failed subrequest as an error, or nil.
+func (s Subvalue) Err() error {
+	if s.Success() {
+		return nil
+	}
+	errs := s.Errors()
+	if len(errs) == 0 {
+		return fmt.Errorf("composite subrequest %s: %d", s.ReferenceID, s.HTTPStatusCode)
+	}
+	return errors.Wrap(sfdc.Errors(errs), "composite subrequest "+s.ReferenceID)
+}
+
 const endpoint = "/composite"
 
 var invalidHTTPHeader = map[string]struct{}{
@@ -73,7 +140,8 @@ func NewResource(session session.ServiceFormatter) (*Resource, error) {
 	}, nil
 }
 
-// Retrieve will retrieve the responses to a composite requests.
+// Retrieve will retrieve the responses to a composite requests.  When allOrNone
+// is true, all of the subrequests are rolled back if any subrequest fails.
 func (r *Resource) Retrieve(allOrNone bool, requesters []Subrequester) (Value, error) {
 	if requesters == nil {
 		return Value{}, errors.New("composite subrequests: requesters can not nil")
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/enrique-esquivel/go-sfdc"
 	"github.com/enrique-esquivel/go-sfdc/session"
 )
 
@@ -492,3 +493,81 @@ func TestResource_Retrieve(t *testing.T) {
 		})
 	}
 }
+
+func TestValue_HasErrors(t *testing.T) {
+	value := Value{
+		Response: []Subvalue{
+			{
+				Body: map[string]interface{}{
+					"id":      "001R00000033JNuIAM",
+					"success": true,
+					"errors":  make([]interface{}, 0),
+				},
+				HTTPStatusCode: 201,
+				ReferenceID:    "NewAccount",
+			},
+			{
+				Body: []interface{}{
+					map[string]interface{}{
+						"errorCode": "PROCESSING_HALTED",
+						"message":   "The transaction was rolled back since another operation in the same transaction failed.",
+					},
+				},
+				HTTPStatusCode: 400,
+				ReferenceID:    "NewContact",
+			},
+		},
+	}
+	tests := []struct {
+		name        string
+		value       Value
+		wantErrors  bool
+		wantFailed  []string
+		wantSubErrs []sfdc.Error
+	}{
+		{
+			name:       "partial success",
+			value:      value,
+			wantErrors: true,
+			wantFailed: []string{"NewContact"},
+			wantSubErrs: []sfdc.Error{
+				{
+					ErrorCode: "PROCESSING_HALTED",
+					Message:   "The transaction was rolled back since another operation in the same transaction failed.",
+				},
+			},
+		},
+		{
+			name: "success",
+			value: Value{
+				Response: value.Response[:1],
+			},
+			wantErrors: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.value.HasErrors(); got != tt.wantErrors {
+				t.Errorf("Value.HasErrors() = %v, want %v", got, tt.wantErrors)
+			}
+			var failed []string
+			var errs []sfdc.Error
+			for _, subvalue := range tt.value.Failed() {
+				failed = append(failed, subvalue.ReferenceID)
+				errs = append(errs, subvalue.Errors()...)
+				if subvalue.Err() == nil {
+					t.Errorf("Subvalue.Err() = nil for %s", subvalue.ReferenceID)
+				}
+			}
+			if !reflect.DeepEqual(failed, tt.wantFailed) {
+				t.Errorf("Value.Failed() = %v, want %v", failed, tt.wantFailed)
+			}
+			if !reflect.DeepEqual(errs, tt.wantSubErrs) {
+				t.Errorf("Subvalue.Errors() = %v, want %v", errs, tt.wantSubErrs)
+			}
+			if _, has := tt.value.Subvalue("NewAccount"); has == false {
+				t.Errorf("Value.Subvalue() did not find NewAccount")
+			}
+		})
+	}
+}
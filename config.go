@@ -1,7 +1,9 @@
 package sfdc
 
 import (
+	"crypto/tls"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/enrique-esquivel/go-sfdc/credentials"
@@ -14,9 +16,41 @@ import (
 // Client is the HTTP client that will be used.
 //
 // Version is the Salesforce version for the APIs.
+//
+// SessionDuration is the lifetime of the access token, from when it was issued, which
+// should match the org's session timeout.  If zero, the lifetime is 24 hours.
+//
+// RefreshMargin is how long before the access token expires that the session is refreshed,
+// by the next request, so the request is not refused with an expired token.  If zero, the
+// margin is 5 minutes.
+//
+// DefaultHeaders are added to every API request made with the session, unless
+// the request already sets the header.
+//
+// ProxyURL is the proxy used to reach Salesforce.  TLSConfig is the TLS configuration,
+// like a custom root CA, used to reach Salesforce.  Both are applied to a copy of the
+// client's transport, which must be a *http.Transport.
+//
+// RetryPolicy is the retry behavior for throttled requests.  If nil, requests are not retried.
+//
+// Tracer is notified at the start and end of every request.  If nil, requests are not traced.
+//
+// UserAgent is the User-Agent header of every request, unless the request already sets it.
+// If empty, the user agent is go-sfdc with the module version, like go-sfdc/v1.2.0.
+//
+// RateLimit is the most requests per second made with the session, shared by all of the
+// resources.  Requests block until they are allowed.  If zero, requests are not limited.
 type Configuration struct {
 	Credentials     *credentials.Credentials
 	Client          *http.Client
 	Version         int
 	SessionDuration time.Duration
+	RefreshMargin   time.Duration
+	DefaultHeaders  map[string]string
+	ProxyURL        *url.URL
+	TLSConfig       *tls.Config
+	RetryPolicy     *RetryPolicy
+	Tracer          Tracer
+	UserAgent       string
+	RateLimit       int
 }
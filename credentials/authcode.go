@@ -0,0 +1,213 @@
+package credentials
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+const authorizeEndpoint = "/services/oauth2/authorize"
+
+// AuthCodeCredentials are the connected application's information for the web
+// server flow with PKCE, which is used by interactive tools to log in the user
+// with the browser.
+//
+// RedirectURL is the callback URL of the connected application, which needs to
+// be a localhost URL, like http://localhost:1717/OauthRedirect, to capture the
+// code.
+//
+// ClientSecret is optional, since the connected application can be configured
+// to not require it with PKCE.
+//
+// Params are additional parameters for the authorize request, like scope or
+// prompt.  This field is optional.
+type AuthCodeCredentials struct {
+	URL          string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Params       map[string]string
+}
+
+// AuthCodeFlow drives the web server flow with PKCE.  The user is sent to the
+// authorize URL, the code is captured by the localhost callback server and then
+// exchanged for the refresh token credentials.
+type AuthCodeFlow struct {
+	creds     AuthCodeCredentials
+	redirect  *url.URL
+	verifier  string
+	challenge string
+	state     string
+}
+
+// NewAuthCodeFlow creates the flow with a new code verifier, challenge and
+// state.  A flow is used for one log in.
+func NewAuthCodeFlow(creds AuthCodeCredentials) (*AuthCodeFlow, error) {
+	if err := validateAuthCodeCredentials(creds); err != nil {
+		return nil, err
+	}
+	redirect, err := url.Parse(creds.RedirectURL)
+	if err != nil {
+		return nil, fmt.Errorf("credentials: auth code credential's redirect URL: %w", err)
+	}
+	if redirect.Scheme != "http" || redirect.Port() == "" {
+		return nil, errors.New("credentials: auth code credential's redirect URL must be http with a port")
+	}
+
+	verifier, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(verifier))
+
+	return &AuthCodeFlow{
+		creds:     creds,
+		redirect:  redirect,
+		verifier:  verifier,
+		challenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+		state:     state,
+	}, nil
+}
+
+// AuthorizeURL is the URL to open in the browser for the user to log in.
+func (flow *AuthCodeFlow) AuthorizeURL() string {
+	query := url.Values{}
+	query.Add("response_type", "code")
+	query.Add("client_id", flow.creds.ClientID)
+	query.Add("redirect_uri", flow.creds.RedirectURL)
+	query.Add("code_challenge", flow.challenge)
+	query.Add("code_challenge_method", "S256")
+	query.Add("state", flow.state)
+	addParams(query, flow.creds.Params)
+
+	return flow.creds.URL + authorizeEndpoint + "?" + query.Encode()
+}
+
+// WaitForCode runs the callback server on the redirect URL's port until the
+// code is returned from the browser.  If the user denies access, an *OAuthError
+// with the access_denied code is returned.  If the context is done first, the context error is
+// returned.
+func (flow *AuthCodeFlow) WaitForCode(ctx context.Context) (string, error) {
+	listener, err := net.Listen("tcp", flow.redirect.Host)
+	if err != nil {
+		return "", fmt.Errorf("credentials: callback server: %w", err)
+	}
+	return flow.serve(ctx, listener)
+}
+
+type callbackResult struct {
+	code string
+	err  error
+}
+
+func (flow *AuthCodeFlow) serve(ctx context.Context, listener net.Listener) (string, error) {
+	results := make(chan callbackResult, 1)
+	mux := http.NewServeMux()
+	path := flow.redirect.Path
+	if path == "" {
+		path = "/"
+	}
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		result := flow.callback(r.URL.Query())
+		if result.err != nil {
+			http.Error(w, result.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Authenticated, this window can be closed.")
+		}
+		select {
+		case results <- result:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case result := <-results:
+		return result.code, result.err
+	}
+}
+
+func (flow *AuthCodeFlow) callback(query url.Values) callbackResult {
+	if query.Get("state") != flow.state {
+		return callbackResult{err: errors.New("credentials: callback state does not match")}
+	}
+	if code := query.Get("error"); code != "" {
+		return callbackResult{
+			err: fmt.Errorf("credentials: %w", &OAuthError{
+				Code:        code,
+				Description: query.Get("error_description"),
+			}),
+		}
+	}
+	code := query.Get("code")
+	if code == "" {
+		return callbackResult{err: errors.New("credentials: callback has no code")}
+	}
+	return callbackResult{code: code}
+}
+
+// Exchange exchanges the code for the tokens, with the code verifier, and returns
+// the refresh token credentials to open the session.  The connected application
+// needs the refresh_token scope.  If Salesforce refuses the code, like with
+// invalid_grant, an *OAuthError is returned.
+func (flow *AuthCodeFlow) Exchange(client *http.Client, code string) (*Credentials, error) {
+	if client == nil {
+		return nil, errors.New("credentials: the HTTP client can not be nil")
+	}
+	if code == "" {
+		return nil, errors.New("credentials: the code can not be empty")
+	}
+
+	form := url.Values{}
+	form.Add("grant_type", "authorization_code")
+	form.Add("format", "json")
+	form.Add("code", code)
+	form.Add("client_id", flow.creds.ClientID)
+	if flow.creds.ClientSecret != "" {
+		form.Add("client_secret", flow.creds.ClientSecret)
+	}
+	form.Add("redirect_uri", flow.creds.RedirectURL)
+	form.Add("code_verifier", flow.verifier)
+
+	var token tokenResponse
+	if err := postForm(client, flow.creds.URL+tokenEndpoint, form, &token); err != nil {
+		return nil, err
+	}
+	return refreshCredentials(flow.creds.URL, flow.creds.ClientID, flow.creds.ClientSecret, token)
+}
+
+func randomString() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("credentials: random: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func validateAuthCodeCredentials(cred AuthCodeCredentials) error {
+	if cred.URL == "" {
+		return errors.New("credentials: auth code credential's URL can not be empty")
+	}
+	if cred.ClientID == "" {
+		return errors.New("credentials: auth code credential's client ID can not be empty")
+	}
+	if cred.RedirectURL == "" {
+		return errors.New("credentials: auth code credential's redirect URL can not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,324 @@
+package credentials
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNewAuthCodeFlow(t *testing.T) {
+	tests := []struct {
+		name    string
+		creds   AuthCodeCredentials
+		wantErr bool
+	}{
+		{
+			name: "Passing",
+			creds: AuthCodeCredentials{
+				URL:         "https://login.salesforce.com",
+				ClientID:    "client id",
+				RedirectURL: "http://localhost:1717/OauthRedirect",
+			},
+			wantErr: false,
+		},
+		{
+			name: "No URL",
+			creds: AuthCodeCredentials{
+				ClientID:    "client id",
+				RedirectURL: "http://localhost:1717/OauthRedirect",
+			},
+			wantErr: true,
+		},
+		{
+			name: "No Client ID",
+			creds: AuthCodeCredentials{
+				URL:         "https://login.salesforce.com",
+				RedirectURL: "http://localhost:1717/OauthRedirect",
+			},
+			wantErr: true,
+		},
+		{
+			name: "No Redirect URL",
+			creds: AuthCodeCredentials{
+				URL:      "https://login.salesforce.com",
+				ClientID: "client id",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Redirect URL Without Port",
+			creds: AuthCodeCredentials{
+				URL:         "https://login.salesforce.com",
+				ClientID:    "client id",
+				RedirectURL: "http://localhost/OauthRedirect",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAuthCodeFlow(tt.creds)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewAuthCodeFlow() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			sum := sha256.Sum256([]byte(got.verifier))
+			if got.challenge != base64.RawURLEncoding.EncodeToString(sum[:]) {
+				t.Errorf("NewAuthCodeFlow() challenge = %v, is not the S256 of the verifier", got.challenge)
+			}
+			if got.verifier == "" || got.state == "" || got.verifier == got.state {
+				t.Errorf("NewAuthCodeFlow() verifier = %v, state = %v", got.verifier, got.state)
+			}
+		})
+	}
+}
+
+func TestAuthCodeFlow_AuthorizeURL(t *testing.T) {
+	flow, err := NewAuthCodeFlow(AuthCodeCredentials{
+		URL:         "https://login.salesforce.com",
+		ClientID:    "client id",
+		RedirectURL: "http://localhost:1717/OauthRedirect",
+		Params: map[string]string{
+			"scope": "api refresh_token",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewAuthCodeFlow() error = %v", err)
+	}
+
+	authorize, err := url.Parse(flow.AuthorizeURL())
+	if err != nil {
+		t.Fatalf("AuthCodeFlow.AuthorizeURL() error = %v", err)
+	}
+	if authorize.Host != "login.salesforce.com" || authorize.Path != "/services/oauth2/authorize" {
+		t.Errorf("AuthCodeFlow.AuthorizeURL() = %v", authorize)
+	}
+	want := map[string]string{
+		"response_type":         "code",
+		"client_id":             "client id",
+		"redirect_uri":          "http://localhost:1717/OauthRedirect",
+		"code_challenge":        flow.challenge,
+		"code_challenge_method": "S256",
+		"state":                 flow.state,
+		"scope":                 "api refresh_token",
+	}
+	query := authorize.Query()
+	for key, value := range want {
+		if query.Get(key) != value {
+			t.Errorf("AuthCodeFlow.AuthorizeURL() %s = %v, want %v", key, query.Get(key), value)
+		}
+	}
+}
+
+func TestAuthCodeFlow_callback(t *testing.T) {
+	flow := &AuthCodeFlow{
+		state: "state",
+	}
+	tests := []struct {
+		name         string
+		query        url.Values
+		want         string
+		wantErr      bool
+		wantOAuthErr bool
+	}{
+		{
+			name: "Passing",
+			query: url.Values{
+				"state": []string{"state"},
+				"code":  []string{"the code"},
+			},
+			want:    "the code",
+			wantErr: false,
+		},
+		{
+			name: "State Mismatch",
+			query: url.Values{
+				"state": []string{"other"},
+				"code":  []string{"the code"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Access Denied",
+			query: url.Values{
+				"state":             []string{"state"},
+				"error":             []string{"access_denied"},
+				"error_description": []string{"end-user denied authorization"},
+			},
+			wantErr:      true,
+			wantOAuthErr: true,
+		},
+		{
+			name: "No Code",
+			query: url.Values{
+				"state": []string{"state"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flow.callback(tt.query)
+			var oauthErr *OAuthError
+			if errors.As(got.err, &oauthErr) != tt.wantOAuthErr {
+				t.Errorf("AuthCodeFlow.callback() error = %v, want an OAuthError %v", got.err, tt.wantOAuthErr)
+			}
+			if (got.err != nil) != tt.wantErr {
+				t.Errorf("AuthCodeFlow.callback() error = %v, wantErr %v", got.err, tt.wantErr)
+				return
+			}
+			if got.code != tt.want {
+				t.Errorf("AuthCodeFlow.callback() = %v, want %v", got.code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthCodeFlow_serve(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	redirect, _ := url.Parse(fmt.Sprintf("http://%s/OauthRedirect", listener.Addr().String()))
+	flow := &AuthCodeFlow{
+		redirect: redirect,
+		state:    "state",
+	}
+
+	go func() {
+		response, err := http.Get(redirect.String() + "?state=state&code=the+code")
+		if err == nil {
+			response.Body.Close()
+		}
+	}()
+
+	got, err := flow.serve(context.Background(), listener)
+	if err != nil {
+		t.Fatalf("AuthCodeFlow.serve() error = %v", err)
+	}
+	if got != "the code" {
+		t.Errorf("AuthCodeFlow.serve() = %v, want %v", got, "the code")
+	}
+}
+
+func TestAuthCodeFlow_serve_Canceled(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	redirect, _ := url.Parse(fmt.Sprintf("http://%s/OauthRedirect", listener.Addr().String()))
+	flow := &AuthCodeFlow{
+		redirect: redirect,
+		state:    "state",
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := flow.serve(ctx, listener); err != context.Canceled {
+		t.Errorf("AuthCodeFlow.serve() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestAuthCodeFlow_Exchange(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		code     string
+		wantErr  bool
+		wantCode string
+	}{
+		{
+			name:    "Passing",
+			status:  http.StatusOK,
+			body:    `{"access_token":"token","refresh_token":"refresh","instance_url":"https://my.salesforce.com"}`,
+			code:    "the code",
+			wantErr: false,
+		},
+		{
+			name:    "No Refresh Token",
+			status:  http.StatusOK,
+			body:    `{"access_token":"token","instance_url":"https://my.salesforce.com"}`,
+			code:    "the code",
+			wantErr: true,
+		},
+		{
+			name:     "OAuth Error",
+			status:   http.StatusBadRequest,
+			body:     `{"error":"invalid_grant","error_description":"invalid authorization code"}`,
+			code:     "the code",
+			wantErr:  true,
+			wantCode: "invalid_grant",
+		},
+		{
+			name:    "No Code",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var form url.Values
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/services/oauth2/token" {
+					http.Error(w, "wrong path", http.StatusNotFound)
+					return
+				}
+				r.ParseForm()
+				form = r.PostForm
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			flow, err := NewAuthCodeFlow(AuthCodeCredentials{
+				URL:         server.URL,
+				ClientID:    "client id",
+				RedirectURL: "http://localhost:1717/OauthRedirect",
+			})
+			if err != nil {
+				t.Fatalf("NewAuthCodeFlow() error = %v", err)
+			}
+
+			got, err := flow.Exchange(server.Client(), tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AuthCodeFlow.Exchange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var oauthErr *OAuthError
+			if errors.As(err, &oauthErr) && (oauthErr.Code != tt.wantCode || oauthErr.StatusCode != tt.status) {
+				t.Errorf("AuthCodeFlow.Exchange() error = %+v, want code %v", oauthErr, tt.wantCode)
+			} else if oauthErr == nil && tt.wantCode != "" {
+				t.Errorf("AuthCodeFlow.Exchange() error = %v, want an OAuthError", err)
+			}
+			if tt.wantErr {
+				return
+			}
+			if form.Get("grant_type") != "authorization_code" || form.Get("code_verifier") != flow.verifier || form.Get("code") != tt.code {
+				t.Errorf("AuthCodeFlow.Exchange() form = %v", form)
+			}
+			if form.Get("client_secret") != "" {
+				t.Errorf("AuthCodeFlow.Exchange() client_secret = %v, want none", form.Get("client_secret"))
+			}
+			if got.URL() != server.URL {
+				t.Errorf("AuthCodeFlow.Exchange() URL = %v, want %v", got.URL(), server.URL)
+			}
+			reader, _ := got.Retrieve()
+			body, _ := ioutil.ReadAll(reader)
+			if strings.Contains(string(body), "refresh_token=refresh") == false {
+				t.Errorf("AuthCodeFlow.Exchange() credentials = %v", string(body))
+			}
+		})
+	}
+}
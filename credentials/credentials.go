@@ -3,6 +3,7 @@ package credentials
 import (
 	"errors"
 	"io"
+	"net/url"
 )
 
 // Credentials is the structure that contains all of the
@@ -41,3 +42,11 @@ func NewCredentials(provider Provider) (*Credentials, error) {
 		provider: provider,
 	}, nil
 }
+
+// addParams sets the additional parameters of the token request, which replace
+// the parameters of the same name.
+func addParams(form url.Values, params map[string]string) {
+	for key, value := range params {
+		form.Set(key, value)
+	}
+}
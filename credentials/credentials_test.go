@@ -226,10 +226,14 @@ func TestCredentials_URL(t *testing.T) {
 func mockCredentialsRetriveReader(creds PasswordCredentials) io.Reader {
 	form := url.Values{}
 	form.Add("grant_type", string(passwordGrantType))
+	form.Add("format", "json")
 	form.Add("username", creds.Username)
 	form.Add("password", creds.Password)
 	form.Add("client_id", creds.ClientID)
 	form.Add("client_secret", creds.ClientSecret)
+	for key, value := range creds.Params {
+		form.Set(key, value)
+	}
 
 	return strings.NewReader(form.Encode())
 }
@@ -0,0 +1,144 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	authorizationPending = "authorization_pending"
+	slowDown             = "slow_down"
+	defaultPollInterval  = 5
+)
+
+// DeviceCredentials are the connected application's information for the device
+// flow, which is used by tools that run without a browser.  The user logs in on
+// another device with the user code.
+//
+// ClientSecret is optional, since the connected application does not require it
+// for the device flow.
+//
+// Params are additional parameters for the code request, like scope.  This field
+// is optional.
+type DeviceCredentials struct {
+	URL          string
+	ClientID     string
+	ClientSecret string
+	Params       map[string]string
+}
+
+// DeviceCode is the code returned by Salesforce for the device flow.  The user
+// code is entered by the user at the verification URI.  The interval is the
+// number of seconds to wait between polls.
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	Interval        int    `json:"interval"`
+}
+
+// DeviceFlow drives the device flow.  The device code is requested, the user
+// code is displayed with the verification URI and the token endpoint is polled
+// until the user approves.
+type DeviceFlow struct {
+	creds  DeviceCredentials
+	second time.Duration
+}
+
+// NewDeviceFlow creates the device flow.
+func NewDeviceFlow(creds DeviceCredentials) (*DeviceFlow, error) {
+	if err := validateDeviceCredentials(creds); err != nil {
+		return nil, err
+	}
+	return &DeviceFlow{
+		creds:  creds,
+		second: time.Second,
+	}, nil
+}
+
+// RequestCode requests the device and user codes.  The user code and the
+// verification URI need to be displayed to the user.
+func (flow *DeviceFlow) RequestCode(client *http.Client) (DeviceCode, error) {
+	if client == nil {
+		return DeviceCode{}, errors.New("credentials: the HTTP client can not be nil")
+	}
+
+	form := url.Values{}
+	form.Add("response_type", "device_code")
+	form.Add("client_id", flow.creds.ClientID)
+	addParams(form, flow.creds.Params)
+
+	var code DeviceCode
+	if err := postForm(client, flow.creds.URL+tokenEndpoint, form, &code); err != nil {
+		return DeviceCode{}, err
+	}
+	if code.DeviceCode == "" {
+		return DeviceCode{}, errors.New("credentials: no device code was returned")
+	}
+	return code, nil
+}
+
+// Poll polls the token endpoint at the code's interval until the user approves,
+// and returns the refresh token credentials to open the session.  The interval
+// is increased when Salesforce asks to slow down.  If the user denies access or
+// the code expires, an *OAuthError with the access_denied or expired_token code is
+// returned, so it can be told apart from a network error.  If the context is done
+// first, the context error is returned.
+func (flow *DeviceFlow) Poll(ctx context.Context, client *http.Client, code DeviceCode) (*Credentials, error) {
+	if client == nil {
+		return nil, errors.New("credentials: the HTTP client can not be nil")
+	}
+	if code.DeviceCode == "" {
+		return nil, errors.New("credentials: the device code can not be empty")
+	}
+
+	interval := code.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	form := url.Values{}
+	form.Add("grant_type", "device")
+	form.Add("format", "json")
+	form.Add("client_id", flow.creds.ClientID)
+	if flow.creds.ClientSecret != "" {
+		form.Add("client_secret", flow.creds.ClientSecret)
+	}
+	form.Add("code", code.DeviceCode)
+
+	for {
+		timer := time.NewTimer(time.Duration(interval) * flow.second)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		var token tokenResponse
+		err := postForm(client, flow.creds.URL+tokenEndpoint, form, &token)
+		var oauthErr *OAuthError
+		switch {
+		case err == nil:
+			return refreshCredentials(flow.creds.URL, flow.creds.ClientID, flow.creds.ClientSecret, token)
+		case errors.As(err, &oauthErr) && oauthErr.Code == authorizationPending:
+		case errors.As(err, &oauthErr) && oauthErr.Code == slowDown:
+			interval += defaultPollInterval
+		default:
+			return nil, err
+		}
+	}
+}
+
+func validateDeviceCredentials(cred DeviceCredentials) error {
+	if cred.URL == "" {
+		return errors.New("credentials: device credential's URL can not be empty")
+	}
+	if cred.ClientID == "" {
+		return errors.New("credentials: device credential's client ID can not be empty")
+	}
+	return nil
+}
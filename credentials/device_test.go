@@ -0,0 +1,209 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewDeviceFlow(t *testing.T) {
+	tests := []struct {
+		name    string
+		creds   DeviceCredentials
+		wantErr bool
+	}{
+		{
+			name: "Passing",
+			creds: DeviceCredentials{
+				URL:      "https://login.salesforce.com",
+				ClientID: "client id",
+			},
+			wantErr: false,
+		},
+		{
+			name: "No URL",
+			creds: DeviceCredentials{
+				ClientID: "client id",
+			},
+			wantErr: true,
+		},
+		{
+			name: "No Client ID",
+			creds: DeviceCredentials{
+				URL: "https://login.salesforce.com",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDeviceFlow(tt.creds)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewDeviceFlow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeviceFlow_RequestCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    DeviceCode
+		wantErr bool
+	}{
+		{
+			name:   "Passing",
+			status: http.StatusOK,
+			body:   `{"device_code":"device","user_code":"ABCD1234","verification_uri":"https://login.salesforce.com/setup/connect","interval":5}`,
+			want: DeviceCode{
+				DeviceCode:      "device",
+				UserCode:        "ABCD1234",
+				VerificationURI: "https://login.salesforce.com/setup/connect",
+				Interval:        5,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "No Device Code",
+			status:  http.StatusOK,
+			body:    `{}`,
+			wantErr: true,
+		},
+		{
+			name:    "OAuth Error",
+			status:  http.StatusBadRequest,
+			body:    `{"error":"invalid_client_id","error_description":"client identifier invalid"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+				if r.PostForm.Get("response_type") != "device_code" || r.PostForm.Get("scope") != "api refresh_token" {
+					http.Error(w, "wrong form", http.StatusTeapot)
+					return
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			flow, _ := NewDeviceFlow(DeviceCredentials{
+				URL:      server.URL,
+				ClientID: "client id",
+				Params: map[string]string{
+					"scope": "api refresh_token",
+				},
+			})
+			got, err := flow.RequestCode(server.Client())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeviceFlow.RequestCode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("DeviceFlow.RequestCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeviceFlow_Poll(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []string
+		wantPolls int
+		wantErr   bool
+		wantCode  string
+	}{
+		{
+			name: "Approved",
+			responses: []string{
+				`{"error":"authorization_pending","error_description":"authorization pending"}`,
+				`{"error":"slow_down","error_description":"polling too quickly"}`,
+				`{"access_token":"token","refresh_token":"refresh","instance_url":"https://my.salesforce.com"}`,
+			},
+			wantPolls: 3,
+			wantErr:   false,
+		},
+		{
+			name: "Denied",
+			responses: []string{
+				`{"error":"authorization_pending","error_description":"authorization pending"}`,
+				`{"error":"access_denied","error_description":"end-user denied authorization"}`,
+			},
+			wantPolls: 2,
+			wantErr:   true,
+			wantCode:  "access_denied",
+		},
+		{
+			name: "Expired",
+			responses: []string{
+				`{"error":"expired_token","error_description":"device code has expired"}`,
+			},
+			wantPolls: 1,
+			wantErr:   true,
+			wantCode:  "expired_token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+				if r.PostForm.Get("grant_type") != "device" || r.PostForm.Get("code") != "device" {
+					http.Error(w, "wrong form", http.StatusTeapot)
+					return
+				}
+				body := tt.responses[polls]
+				polls++
+				if polls < len(tt.responses) || tt.wantErr {
+					w.WriteHeader(http.StatusBadRequest)
+				}
+				fmt.Fprint(w, body)
+			}))
+			defer server.Close()
+
+			flow, _ := NewDeviceFlow(DeviceCredentials{
+				URL:      server.URL,
+				ClientID: "client id",
+			})
+			flow.second = time.Millisecond
+
+			got, err := flow.Poll(context.Background(), server.Client(), DeviceCode{DeviceCode: "device", Interval: 1})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeviceFlow.Poll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var oauthErr *OAuthError
+			if tt.wantCode != "" && (errors.As(err, &oauthErr) == false || oauthErr.Code != tt.wantCode) {
+				t.Errorf("DeviceFlow.Poll() error = %v, want an OAuthError with code %v", err, tt.wantCode)
+			}
+			if polls != tt.wantPolls {
+				t.Errorf("DeviceFlow.Poll() polls = %d, want %d", polls, tt.wantPolls)
+			}
+			if tt.wantErr == false && got.URL() != server.URL {
+				t.Errorf("DeviceFlow.Poll() URL = %v, want %v", got.URL(), server.URL)
+			}
+		})
+	}
+}
+
+func TestDeviceFlow_Poll_Canceled(t *testing.T) {
+	flow, _ := NewDeviceFlow(DeviceCredentials{
+		URL:      "https://login.salesforce.com",
+		ClientID: "client id",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := flow.Poll(ctx, http.DefaultClient, DeviceCode{DeviceCode: "device"}); err != context.Canceled {
+		t.Errorf("DeviceFlow.Poll() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -25,12 +25,16 @@ const (
 // ClientID is the client ID from the connected application.
 //
 // ClientSecret is the client secret from the connected application.
+//
+// Params are additional parameters for the token request, like scope, which some
+// connected applications require.  This field is optional.
 type PasswordCredentials struct {
 	URL          string
 	Username     string
 	Password     string
 	ClientID     string
 	ClientSecret string
+	Params       map[string]string
 }
 
 type passwordProvider struct {
@@ -40,10 +44,12 @@ type passwordProvider struct {
 func (provider *passwordProvider) Retrieve() (io.Reader, error) {
 	form := url.Values{}
 	form.Add("grant_type", string(passwordGrantType))
+	form.Add("format", "json")
 	form.Add("username", provider.creds.Username)
 	form.Add("password", provider.creds.Password)
 	form.Add("client_id", provider.creds.ClientID)
 	form.Add("client_secret", provider.creds.ClientSecret)
+	addParams(form, provider.creds.Params)
 
 	return strings.NewReader(form.Encode()), nil
 }
@@ -11,10 +11,14 @@ import (
 func mockPasswordRetriveReader(creds PasswordCredentials) io.Reader {
 	form := url.Values{}
 	form.Add("grant_type", string(passwordGrantType))
+	form.Add("format", "json")
 	form.Add("username", creds.Username)
 	form.Add("password", creds.Password)
 	form.Add("client_id", creds.ClientID)
 	form.Add("client_secret", creds.ClientSecret)
+	for key, value := range creds.Params {
+		form.Set(key, value)
+	}
 
 	return strings.NewReader(form.Encode())
 }
@@ -49,6 +53,32 @@ func Test_passwordProvider_Retrieve(t *testing.T) {
 			}),
 			wantErr: false,
 		},
+		{
+			name: "Password Retriever With Params",
+			fields: fields{
+				creds: PasswordCredentials{
+					URL:          "http://test.password.session",
+					Username:     "myusername",
+					Password:     "12345",
+					ClientID:     "some client id",
+					ClientSecret: "shhhh its a secret",
+					Params: map[string]string{
+						"scope": "api refresh_token",
+					},
+				},
+			},
+			want: mockPasswordRetriveReader(PasswordCredentials{
+				URL:          "http://test.password.session",
+				Username:     "myusername",
+				Password:     "12345",
+				ClientID:     "some client id",
+				ClientSecret: "shhhh its a secret",
+				Params: map[string]string{
+					"scope": "api refresh_token",
+				},
+			}),
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -9,11 +9,15 @@ import (
 
 // RefreshTokenCredentials allows a client to
 // obtain an access token
+//
+// Params are additional parameters for the token request, like scope, which some
+// connected applications require.  This field is optional.
 type RefreshTokenCredentials struct {
 	URL          string
 	RefreshToken string
 	ClientID     string
 	ClientSecret string
+	Params       map[string]string
 }
 
 type refreshTokenProvider struct {
@@ -26,7 +30,10 @@ func (provider *refreshTokenProvider) Retrieve() (io.Reader, error) {
 	form.Add("format", "json")
 	form.Add("refresh_token", provider.creds.RefreshToken)
 	form.Add("client_id", provider.creds.ClientID)
-	form.Add("client_secret", provider.creds.ClientSecret)
+	if provider.creds.ClientSecret != "" {
+		form.Add("client_secret", provider.creds.ClientSecret)
+	}
+	addParams(form, provider.creds.Params)
 
 	return strings.NewReader(form.Encode()), nil
 }
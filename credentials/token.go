@@ -0,0 +1,84 @@
+package credentials
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const tokenEndpoint = "/services/oauth2/token"
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	InstanceURL  string `json:"instance_url"`
+}
+
+// OAuthError is the error returned by the OAuth endpoints, like invalid_grant for
+// bad credentials or a bad code, access_denied when the user denies access and
+// expired_token when a device code expires.  These errors will fail again if they
+// are retried.  The sfdc.AuthError of the session is the same type.
+type OAuthError struct {
+	StatusCode  int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *OAuthError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
+// postForm posts the form to the OAuth endpoint and decodes the response to the
+// value.  An OAuth error response is returned as an *OAuthError.
+func postForm(client *http.Client, endpoint string, form url.Values, value interface{}) error {
+	request, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Add("Accept", "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		oauthErr := &OAuthError{}
+		if err := json.Unmarshal(body, oauthErr); err == nil && oauthErr.Code != "" {
+			oauthErr.StatusCode = response.StatusCode
+			return fmt.Errorf("credentials: %w", oauthErr)
+		}
+		return fmt.Errorf("credentials: %s: %s", response.Status, string(body))
+	}
+
+	return json.Unmarshal(body, value)
+}
+
+// refreshCredentials are the refresh token credentials of the token response, to
+// open the session.  The client secret can be empty for a connected application
+// that does not require it.
+func refreshCredentials(loginURL, clientID, clientSecret string, token tokenResponse) (*Credentials, error) {
+	if token.RefreshToken == "" {
+		return nil, fmt.Errorf("credentials: no refresh token was returned, the connected application needs the refresh_token scope")
+	}
+	return &Credentials{
+		provider: &refreshTokenProvider{
+			creds: RefreshTokenCredentials{
+				URL:          loginURL,
+				RefreshToken: token.RefreshToken,
+				ClientID:     clientID,
+				ClientSecret: clientSecret,
+			},
+		},
+	}, nil
+}
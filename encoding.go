@@ -0,0 +1,49 @@
+package sfdc
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const gzipEncoding = "gzip"
+
+// AcceptGzip will request a gzip compressed response from Salesforce.  The response
+// will need to be passed to DecompressResponse.
+func AcceptGzip(request *http.Request) {
+	request.Header.Set("Accept-Encoding", gzipEncoding)
+}
+
+// DecompressResponse will replace the response body with a decompressing
+// reader when Salesforce returned a gzip encoded body.  Closing the response
+// body will close the original body.
+func DecompressResponse(response *http.Response) error {
+	if strings.EqualFold(response.Header.Get("Content-Encoding"), gzipEncoding) == false {
+		return nil
+	}
+
+	reader, err := gzip.NewReader(response.Body)
+	if err != nil {
+		return err
+	}
+	response.Body = &gzipBody{
+		Reader: reader,
+		body:   response.Body,
+	}
+	response.Header.Del("Content-Encoding")
+	response.Header.Del("Content-Length")
+	response.ContentLength = -1
+	response.Uncompressed = true
+	return nil
+}
+
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
@@ -0,0 +1,81 @@
+package sfdc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptGzip(t *testing.T) {
+	request, err := http.NewRequest(http.MethodGet, "https://test.salesforce.com", nil)
+	require.NoError(t, err)
+
+	AcceptGzip(request)
+	assert.Equal(t, "gzip", request.Header.Get("Accept-Encoding"))
+}
+
+func TestDecompressResponse(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, err := writer.Write([]byte(`{"done":true}`))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	tests := []struct {
+		name     string
+		response *http.Response
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "plain",
+			response: &http.Response{
+				Body:   ioutil.NopCloser(strings.NewReader(`{"done":true}`)),
+				Header: make(http.Header),
+			},
+			want: `{"done":true}`,
+		},
+		{
+			name: "gzip",
+			response: &http.Response{
+				Body: ioutil.NopCloser(bytes.NewReader(compressed.Bytes())),
+				Header: http.Header{
+					"Content-Encoding": []string{"gzip"},
+				},
+			},
+			want: `{"done":true}`,
+		},
+		{
+			name: "invalid gzip",
+			response: &http.Response{
+				Body: ioutil.NopCloser(strings.NewReader(`{"done":true}`)),
+				Header: http.Header{
+					"Content-Encoding": []string{"gzip"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DecompressResponse(tt.response)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer tt.response.Body.Close()
+
+			body, err := ioutil.ReadAll(tt.response.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(body))
+			assert.Empty(t, tt.response.Header.Get("Content-Encoding"))
+		})
+	}
+}
@@ -1,24 +1,32 @@
 package sfdc
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
 
+	"github.com/enrique-esquivel/go-sfdc/credentials"
 	"github.com/pkg/errors"
 )
 
-// Error is the error structure defined by the Salesforce API.
+// Error is the error structure defined by the Salesforce API.  The OAuth error
+// structure, with error and error_description, is unmarshaled to the ErrorCode
+// and Message.
 type Error struct {
 	ErrorCode string   `json:"errorCode"`
 	Message   string   `json:"message"`
 	Fields    []string `json:"fields"`
 }
 
-// Error fulfills the error interface and allows us to return SFDC Errors from Go functions
+// Error fulfills the error interface and allows us to return SFDC Errors from Go functions.
+// The fields are only added when the error has fields.
 func (e Error) Error() string {
+	if len(e.Fields) == 0 {
+		return fmt.Sprintf("%s: %s", e.ErrorCode, e.Message)
+	}
 	return fmt.Sprintf("%s: %s (%s)", e.ErrorCode, e.Message, strings.Join(e.Fields, ", "))
 }
 
@@ -48,6 +56,20 @@ func (e *Error) UnmarshalJSON(data []byte) error {
 			return errors.New("json error: errorCode is not a string")
 		}
 	}
+	if code, ok := jsonMap["error"]; ok {
+		if codeStr, ok := code.(string); ok {
+			e.ErrorCode = codeStr
+		} else {
+			return errors.New("json error: error is not a string")
+		}
+	}
+	if description, ok := jsonMap["error_description"]; ok {
+		if descriptionStr, ok := description.(string); ok {
+			e.Message = descriptionStr
+		} else {
+			return errors.New("json error: error_description is not a string")
+		}
+	}
 	if message, ok := jsonMap["message"]; ok {
 		if messageStr, ok := message.(string); ok {
 			e.Message = messageStr
@@ -84,7 +106,82 @@ func (e Errors) Error() string {
 	return strings.Join(msgs, ", ")
 }
 
-// HandleError makes an error from http.Response.
+// ValidationErrors is a collection of validation failures, so that all of the
+// problems can be reported at once.
+type ValidationErrors []error
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Err returns the validation errors as an error, or nil if there are none.
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// AuthError is the OAuth error returned when a session can not be opened, like
+// invalid_grant for bad credentials, which will fail again if it is retried.  It
+// is the credentials.OAuthError that the OAuth flows of the credentials package
+// return, so the same errors.As check detects both.
+type AuthError = credentials.OAuthError
+
+// HandleAuthError makes an error from the http.Response of an OAuth request.  An
+// OAuth error is returned as an *AuthError, and any other body is handled like
+// HandleError.  It is the caller's responsibility to close resp.Body.
+func HandleAuthError(resp *http.Response) error {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(errors.Wrap(err, "could not read the body with error"), resp.Status)
+	}
+
+	authErr := &AuthError{}
+	if err := json.Unmarshal(body, authErr); err == nil && authErr.Code != "" {
+		authErr.StatusCode = resp.StatusCode
+		return errors.Wrap(authErr, resp.Status)
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return HandleError(resp)
+}
+
+// QueryTimeout is the error code of a query that took too long to run.  Queries
+// that time out should be made more selective, or run with a bulk query job.
+const QueryTimeout = "QUERY_TIMEOUT"
+
+// HasErrorCode returns true if the error, or an error that it wraps, is a
+// Salesforce error with the error code.
+func HasErrorCode(err error, code string) bool {
+	var sfdcErrs Errors
+	if errors.As(err, &sfdcErrs) {
+		for _, sfdcErr := range sfdcErrs {
+			if sfdcErr.ErrorCode == code {
+				return true
+			}
+		}
+	}
+	var sfdcErr Error
+	if errors.As(err, &sfdcErr) {
+		return sfdcErr.ErrorCode == code
+	}
+	return false
+}
+
+// IsQueryTimeout returns true if the error is a QUERY_TIMEOUT error.
+func IsQueryTimeout(err error) bool {
+	return HasErrorCode(err, QueryTimeout)
+}
+
+// HandleError makes an error from http.Response.  The body can be an array of
+// Salesforce errors, a single Salesforce error or an OAuth error, which are all
+// returned as Errors.  Any other body is returned as the error message.
 // It is the caller's responsibility to close resp.Body.
 func HandleError(resp *http.Response) error {
 	return errors.Wrap(newErrorFromBody(resp), resp.Status)
@@ -98,8 +195,14 @@ func newErrorFromBody(resp *http.Response) error {
 
 	errs := Errors{}
 	err = json.Unmarshal(body, &errs)
-	if err != nil {
-		return errors.New(string(body))
+	if err == nil {
+		return errs
+	}
+
+	var single Error
+	err = json.Unmarshal(body, &single)
+	if err == nil && (single.ErrorCode != "" || single.Message != "") {
+		return Errors{single}
 	}
-	return errs
+	return errors.New(string(body))
 }
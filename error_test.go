@@ -5,6 +5,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -65,6 +66,22 @@ func TestError_UnmarshalJSON(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:   "Success with OAuth Error",
+			fields: fields{},
+			args: args{
+				data: []byte(`
+				{
+					"error" : "invalid_grant",
+					"error_description" : "authentication failure"
+				}`),
+			},
+			want: &Error{
+				ErrorCode: "invalid_grant",
+				Message:   "authentication failure",
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -75,6 +92,10 @@ func TestError_UnmarshalJSON(t *testing.T) {
 			}
 			if err := e.UnmarshalJSON(tt.args.data); (err != nil) != tt.wantErr {
 				t.Errorf("Error.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.want != nil && !reflect.DeepEqual(e, tt.want) {
+				t.Errorf("Error.UnmarshalJSON() = %v, want %v", e, tt.want)
 			}
 		})
 	}
@@ -86,6 +107,39 @@ func (alwaysError) Read(p []byte) (int, error) {
 	return 0, io.ErrUnexpectedEOF
 }
 
+func TestError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  Error
+		want string
+	}{
+		{
+			name: "No Fields",
+			err: Error{
+				ErrorCode: "INVALID_FIELD",
+				Message:   "No such column",
+			},
+			want: "INVALID_FIELD: No such column",
+		},
+		{
+			name: "Fields",
+			err: Error{
+				ErrorCode: "REQUIRED_FIELD_MISSING",
+				Message:   "Required fields are missing",
+				Fields:    []string{"Name", "Type"},
+			},
+			want: "REQUIRED_FIELD_MISSING: Required fields are missing (Name, Type)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error.Error() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestHandleError(t *testing.T) {
 	const (
 		singleErr       = "{\"message\":\"invalid record id\",\"errorCode\":\"INVALID_ID_FIELD\",\"fields\":[\"id\"]}"
@@ -131,6 +185,40 @@ func TestHandleError(t *testing.T) {
 				},
 			},
 		},
+		"single_object_error": {
+			resp: &http.Response{
+				Status: "400 " + http.StatusText(400),
+				Body:   ioutil.NopCloser(strings.NewReader(singleErr)),
+			},
+			wantErr: `400 Bad Request: INVALID_ID_FIELD: invalid record id (id)`,
+			errors: Errors{
+				{
+					Message:   "invalid record id",
+					ErrorCode: "INVALID_ID_FIELD",
+					Fields:    []string{"id"},
+				},
+			},
+		},
+		"oauth_error": {
+			resp: &http.Response{
+				Status: "400 " + http.StatusText(400),
+				Body:   ioutil.NopCloser(strings.NewReader(`{"error":"invalid_grant","error_description":"authentication failure"}`)),
+			},
+			wantErr: `400 Bad Request: invalid_grant: authentication failure`,
+			errors: Errors{
+				{
+					ErrorCode: "invalid_grant",
+					Message:   "authentication failure",
+				},
+			},
+		},
+		"not_json_error": {
+			resp: &http.Response{
+				Status: "500 " + http.StatusText(500),
+				Body:   ioutil.NopCloser(strings.NewReader("Internal Server Error")),
+			},
+			wantErr: `500 Internal Server Error: Internal Server Error`,
+		},
 		"read_body_error": {
 			resp: &http.Response{
 				Status: "500 " + http.StatusText(500),
@@ -154,3 +242,123 @@ func TestHandleError(t *testing.T) {
 		})
 	}
 }
+
+func TestValidationErrors_Err(t *testing.T) {
+	tests := map[string]struct {
+		errs    ValidationErrors
+		wantErr string
+	}{
+		"none": {
+			errs: nil,
+		},
+		"multiple": {
+			errs: ValidationErrors{
+				errors.New("object is required"),
+				errors.New("operation is required"),
+			},
+			wantErr: "object is required; operation is required",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.errs.Err()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidationErrors.Err() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ValidationErrors.Err() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsQueryTimeout(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"query_timeout": {
+			err: HandleError(&http.Response{
+				Status: "400 " + http.StatusText(400),
+				Body:   ioutil.NopCloser(strings.NewReader(`[{"message":"Your query request was running for too long.","errorCode":"QUERY_TIMEOUT"}]`)),
+			}),
+			want: true,
+		},
+		"single_error": {
+			err:  Error{ErrorCode: QueryTimeout},
+			want: true,
+		},
+		"other_error": {
+			err: HandleError(&http.Response{
+				Status: "400 " + http.StatusText(400),
+				Body:   ioutil.NopCloser(strings.NewReader(`[{"message":"invalid record id","errorCode":"INVALID_ID_FIELD"}]`)),
+			}),
+			want: false,
+		},
+		"not_salesforce": {
+			err:  errors.New("some error"),
+			want: false,
+		},
+		"nil": {
+			want: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsQueryTimeout(tt.err))
+		})
+	}
+}
+
+func TestHandleAuthError(t *testing.T) {
+	tests := map[string]struct {
+		resp    *http.Response
+		wantErr string
+		authErr *AuthError
+	}{
+		"oauth_error": {
+			resp: &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Status:     "400 " + http.StatusText(400),
+				Body:       ioutil.NopCloser(strings.NewReader(`{"error":"invalid_grant","error_description":"authentication failure"}`)),
+			},
+			wantErr: `400 Bad Request: invalid_grant: authentication failure`,
+			authErr: &AuthError{
+				StatusCode:  http.StatusBadRequest,
+				Code:        "invalid_grant",
+				Description: "authentication failure",
+			},
+		},
+		"api_error": {
+			resp: &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Status:     "400 " + http.StatusText(400),
+				Body:       ioutil.NopCloser(strings.NewReader(`[{"message":"invalid record id","errorCode":"INVALID_ID_FIELD","fields":["id"]}]`)),
+			},
+			wantErr: `400 Bad Request: INVALID_ID_FIELD: invalid record id (id)`,
+		},
+		"not_json_error": {
+			resp: &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Status:     "503 " + http.StatusText(503),
+				Body:       ioutil.NopCloser(strings.NewReader("Service Unavailable")),
+			},
+			wantErr: `503 Service Unavailable: Service Unavailable`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := HandleAuthError(tt.resp)
+			require.EqualError(t, err, tt.wantErr)
+
+			var authErr *AuthError
+			require.Equal(t, tt.authErr != nil, errors.As(err, &authErr))
+			if tt.authErr != nil {
+				require.Equal(t, tt.authErr, authErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package sfdc
+
+import (
+	"errors"
+	"strings"
+)
+
+const idChecksumChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ012345"
+
+// ValidID returns true if the ID is a 15 character, case sensitive, or an 18
+// character, case insensitive, Salesforce ID.  The checksum of an 18 character
+// ID is verified, ignoring its case.
+func ValidID(id string) bool {
+	switch len(id) {
+	case 15:
+		return alphanumeric(id)
+	case 18:
+		if alphanumeric(id) == false {
+			return false
+		}
+		return strings.EqualFold(id[15:], idChecksum(id[:15]))
+	default:
+		return false
+	}
+}
+
+// To18 converts a 15 character Salesforce ID to its 18 character form, by
+// appending the checksum of the ID's case.  An 18 character ID is returned
+// unchanged.
+func To18(id15 string) (string, error) {
+	if len(id15) == 18 && ValidID(id15) {
+		return id15, nil
+	}
+	if len(id15) != 15 || alphanumeric(id15) == false {
+		return "", errors.New("sfdc: " + id15 + " is not a 15 character Salesforce ID")
+	}
+	return id15 + idChecksum(id15), nil
+}
+
+// To15 converts an 18 character Salesforce ID to its 15 character form.  The
+// ID is returned unchanged if it is not 18 characters.  The case of the 15
+// character ID is significant, so an 18 character ID that was changed to a
+// different case will not convert to the original ID.
+func To15(id18 string) string {
+	if len(id18) != 18 {
+		return id18
+	}
+	return id18[:15]
+}
+
+// idChecksum returns the three checksum characters of a 15 character ID.  Each
+// character encodes which of five ID characters are upper case.
+func idChecksum(id15 string) string {
+	checksum := make([]byte, 3)
+	for chunk := range checksum {
+		flags := 0
+		for position := 0; position < 5; position++ {
+			c := id15[chunk*5+position]
+			if c >= 'A' && c <= 'Z' {
+				flags |= 1 << position
+			}
+		}
+		checksum[chunk] = idChecksumChars[flags]
+	}
+	return string(checksum)
+}
+
+func alphanumeric(id string) bool {
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
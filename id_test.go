@@ -0,0 +1,127 @@
+package sfdc
+
+import "testing"
+
+func TestValidID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want bool
+	}{
+		{
+			name: "15 characters",
+			id:   "001A0000006Vm9r",
+			want: true,
+		},
+		{
+			name: "18 characters",
+			id:   "001A0000006Vm9rIAC",
+			want: true,
+		},
+		{
+			name: "18 characters lower case checksum",
+			id:   "001A0000006Vm9riac",
+			want: true,
+		},
+		{
+			name: "bad checksum",
+			id:   "001A0000006Vm9rIAD",
+			want: false,
+		},
+		{
+			name: "length",
+			id:   "001A0000006Vm9",
+			want: false,
+		},
+		{
+			name: "not alphanumeric",
+			id:   "001A0000006Vm9-",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidID(tt.id); got != tt.want {
+				t.Errorf("ValidID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTo18(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "account",
+			id:      "001A0000006Vm9r",
+			want:    "001A0000006Vm9rIAC",
+			wantErr: false,
+		},
+		{
+			name:    "contact",
+			id:      "003D000000QOAPw",
+			want:    "003D000000QOAPwIAP",
+			wantErr: false,
+		},
+		{
+			name:    "no upper case",
+			id:      "001000000000000",
+			want:    "001000000000000AAA",
+			wantErr: false,
+		},
+		{
+			name:    "already 18 characters",
+			id:      "001A0000006Vm9rIAC",
+			want:    "001A0000006Vm9rIAC",
+			wantErr: false,
+		},
+		{
+			name:    "invalid",
+			id:      "001A0000006Vm9",
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := To18(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("To18() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("To18() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTo15(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want string
+	}{
+		{
+			name: "18 characters",
+			id:   "001A0000006Vm9rIAC",
+			want: "001A0000006Vm9r",
+		},
+		{
+			name: "15 characters",
+			id:   "001A0000006Vm9r",
+			want: "001A0000006Vm9r",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := To15(tt.id); got != tt.want {
+				t.Errorf("To15() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package csvstruct maps the fields of structs to CSV columns with csv tags, for
+// the bulk job data and the bulk query results, and reads the column delimiters
+// of the jobs.
+package csvstruct
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// TimeType is the type of time.Time, which is a column rather than a nested struct.
+var TimeType = reflect.TypeOf(time.Time{})
+
+// Column is a column of a struct's CSV data.
+//
+// Name is the column name, which is prefixed with the columns of the nested structs.
+//
+// Index is the index sequence of the field, like reflect.Value.FieldByIndex, through
+// the nested structs and struct pointers.
+//
+// Null is true when the tag has the null option.
+type Column struct {
+	Name  string
+	Index []int
+	Null  bool
+}
+
+// Columns returns the columns of the struct's exported fields.  The column of a
+// field is the name in the csv tag, or the field name when there is no tag.  A
+// field with the tag "-" is skipped.  The fields of a nested struct, or struct
+// pointer, are columns prefixed with the nested struct's column and a dot.
+//
+// A struct that nests a struct of its own type, like a parent record of the same
+// type, is an error, since its columns would never end.
+func Columns(structType reflect.Type) ([]Column, error) {
+	return columns(structType, "", nil, nil)
+}
+
+func columns(structType reflect.Type, prefix string, index []int, path []reflect.Type) ([]Column, error) {
+	for _, pathType := range path {
+		if pathType == structType {
+			return nil, fmt.Errorf("%s references itself at %s", structType, strings.TrimSuffix(prefix, "."))
+		}
+	}
+	path = append(path[:len(path):len(path)], structType)
+
+	var cols []Column
+	for idx := 0; idx < structType.NumField(); idx++ {
+		field := structType.Field(idx)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := field.Name
+		null := false
+		if tag, ok := field.Tag.Lookup("csv"); ok {
+			if tag == "-" {
+				continue
+			}
+			options := strings.Split(tag, ",")
+			if options[0] != "" {
+				name = options[0]
+			}
+			for _, option := range options[1:] {
+				null = null || option == "null"
+			}
+		}
+		fieldIndex := append(append([]int{}, index...), idx)
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct && fieldType != TimeType {
+			nested, err := columns(fieldType, prefix+name+".", fieldIndex, path)
+			if err != nil {
+				return nil, err
+			}
+			cols = append(cols, nested...)
+			continue
+		}
+		cols = append(cols, Column{
+			Name:  prefix + name,
+			Index: fieldIndex,
+			Null:  null,
+		})
+	}
+	return cols, nil
+}
+
+// Value returns the column's field of the record, or an invalid value when a
+// pointer on the way to the field is nil.
+func (c Column) Value(record reflect.Value) reflect.Value {
+	for _, idx := range c.Index {
+		for record.Kind() == reflect.Ptr {
+			if record.IsNil() {
+				return reflect.Value{}
+			}
+			record = record.Elem()
+		}
+		record = record.Field(idx)
+	}
+	return record
+}
+
+// Field returns the column's field of the record to be set, allocating the nil
+// struct pointers on the way.
+func (c Column) Field(record reflect.Value) reflect.Value {
+	for _, idx := range c.Index {
+		if record.Kind() == reflect.Ptr {
+			if record.IsNil() {
+				record.Set(reflect.New(record.Type().Elem()))
+			}
+			record = record.Elem()
+		}
+		record = record.Field(idx)
+	}
+	return record
+}
@@ -0,0 +1,76 @@
+package csvstruct
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type columnsAccount struct {
+	ExternalID string `csv:"ExternalId__c"`
+}
+
+type columnsParent struct {
+	Name   string
+	Parent *columnsParent
+}
+
+type columnsChild struct {
+	Account struct {
+		Owner struct {
+			Child *columnsChild
+		}
+	}
+}
+
+func TestColumns(t *testing.T) {
+	tests := []struct {
+		name       string
+		structType reflect.Type
+		want       []Column
+		wantErr    bool
+	}{
+		{
+			name: "Nested",
+			structType: reflect.TypeOf(struct {
+				LastName string `csv:"LastName"`
+				Email    string `csv:"Email,null"`
+				Account  columnsAccount
+				Owner    *columnsAccount `csv:"Owner"`
+				Birthday time.Time
+				Ignored  string `csv:"-"`
+				internal string
+			}{}),
+			want: []Column{
+				{Name: "LastName", Index: []int{0}},
+				{Name: "Email", Index: []int{1}, Null: true},
+				{Name: "Account.ExternalId__c", Index: []int{2, 0}},
+				{Name: "Owner.ExternalId__c", Index: []int{3, 0}},
+				{Name: "Birthday", Index: []int{4}},
+			},
+			wantErr: false,
+		},
+		{
+			name:       "Self Reference",
+			structType: reflect.TypeOf(columnsParent{}),
+			wantErr:    true,
+		},
+		{
+			name:       "Indirect Self Reference",
+			structType: reflect.TypeOf(columnsChild{}),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Columns(tt.structType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Columns() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if reflect.DeepEqual(got, tt.want) == false {
+				t.Errorf("Columns() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package csvstruct
+
+// Delimiter returns the character of the Salesforce column delimiter, which is the
+// name of the character like TAB or PIPE.  An empty or unknown delimiter is a
+// comma, which is the Salesforce default.
+func Delimiter(name string) rune {
+	switch name {
+	case "TAB":
+		return '\t'
+	case "SEMICOLON":
+		return ';'
+	case "PIPE":
+		return '|'
+	case "CARET":
+		return '^'
+	case "BACKQUOTE":
+		return '`'
+	default:
+		return ','
+	}
+}
@@ -0,0 +1,39 @@
+// Package pagination detects the pagination loops of the helpers that follow all
+// of the pages of a result.
+package pagination
+
+import (
+	"fmt"
+
+	"github.com/enrique-esquivel/go-sfdc"
+)
+
+// Guard records the pages that are followed, which are the next records URLs or
+// the result locators.
+//
+// MaxPages is the most pages that are followed.  If zero, sfdc.DefaultMaxPages
+// is used.
+type Guard struct {
+	MaxPages int
+	followed map[string]struct{}
+}
+
+// Follow records the page, and returns an error that wraps sfdc.ErrPaginationLoop
+// if the page was already followed or there are too many pages.
+func (g *Guard) Follow(page string) error {
+	if g.followed == nil {
+		g.followed = make(map[string]struct{})
+	}
+	if _, has := g.followed[page]; has {
+		return fmt.Errorf("%w: %s was already followed", sfdc.ErrPaginationLoop, page)
+	}
+	maxPages := g.MaxPages
+	if maxPages <= 0 {
+		maxPages = sfdc.DefaultMaxPages
+	}
+	if len(g.followed) >= maxPages {
+		return fmt.Errorf("%w: more than %d pages", sfdc.ErrPaginationLoop, maxPages)
+	}
+	g.followed[page] = struct{}{}
+	return nil
+}
@@ -0,0 +1,57 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/enrique-esquivel/go-sfdc"
+)
+
+func TestGuard_Follow(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxPages int
+		pages    []string
+		wantErr  bool
+	}{
+		{
+			name:    "Pages",
+			pages:   []string{"/query/01g-2000", "/query/01g-4000", "/query/01g-6000"},
+			wantErr: false,
+		},
+		{
+			name:    "Repeated",
+			pages:   []string{"/query/01g-2000", "/query/01g-4000", "/query/01g-2000"},
+			wantErr: true,
+		},
+		{
+			name:     "Max Pages",
+			maxPages: 2,
+			pages:    []string{"/query/01g-2000", "/query/01g-4000"},
+			wantErr:  false,
+		},
+		{
+			name:     "Too Many",
+			maxPages: 2,
+			pages:    []string{"/query/01g-2000", "/query/01g-4000", "/query/01g-6000"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := Guard{MaxPages: tt.maxPages}
+			var err error
+			for _, page := range tt.pages {
+				if err = guard.Follow(page); err != nil {
+					break
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Guard.Follow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && errors.Is(err, sfdc.ErrPaginationLoop) == false {
+				t.Errorf("Guard.Follow() error = %v, want %v", err, sfdc.ErrPaginationLoop)
+			}
+		})
+	}
+}
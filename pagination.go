@@ -0,0 +1,13 @@
+package sfdc
+
+import "errors"
+
+// DefaultMaxPages is the most pages that are followed by the helpers that follow
+// all of the pages of a result, like soql's QueryByIDs, bulkquery's AllRecords and
+// bulk's PurgeJobs, when their MaxPages is zero.
+const DefaultMaxPages = 100000
+
+// ErrPaginationLoop is returned, wrapped, when a page was already followed or more
+// than the MaxPages pages are followed, which would otherwise loop forever on a
+// malformed response.
+var ErrPaginationLoop = errors.New("pagination loop")
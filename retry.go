@@ -0,0 +1,113 @@
+package sfdc
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy is the retry behavior of a session for requests that Salesforce
+// throttled, which are responses with the status 429 or 503.  Requests with a
+// body that can not be replayed are never retried.
+//
+// MaxRetries is the number of times a request is retried.
+//
+// Backoff is the delay before the first retry, which is doubled on each retry.  When
+// Salesforce sends the Retry-After header, its delay is used instead.
+//
+// MaxBackoff caps the delay between retries.  If zero, the delay is not capped.
+//
+// Jitter is the fraction, from 0 to 1, of the delay that is randomized, so requests that
+// were throttled at the same time are not retried in lockstep.  The backoff is reduced by
+// up to the fraction, and the Retry-After delay is increased by up to the fraction, since
+// Salesforce asked to wait at least that long.  If zero, the delays are not randomized.
+type RetryPolicy struct {
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	Jitter     float64
+}
+
+// jitterSource is seeded when the package is loaded, since the global source of
+// math/rand is not seeded before Go 1.20 and every process would jitter the same.
+// A rand.Rand is not safe for concurrent use, so it is locked.
+var jitterSource = struct {
+	sync.Mutex
+	rand *rand.Rand
+}{
+	rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+}
+
+// jitterRandom returns the random number, from 0 to 1, that the jitter is scaled by.
+var jitterRandom = func() float64 {
+	jitterSource.Lock()
+	defer jitterSource.Unlock()
+	return jitterSource.rand.Float64()
+}
+
+// Retryable returns true if the response status is one that the policy retries.
+func (p *RetryPolicy) Retryable(response *http.Response) bool {
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+// Delay returns the time to wait before the retry attempt, starting at zero.  The
+// response's Retry-After header is honored when present.
+func (p *RetryPolicy) Delay(attempt int, response *http.Response) time.Duration {
+	if delay, ok := RetryAfter(response); ok {
+		return delay + p.jitter(delay)
+	}
+
+	delay := p.Backoff
+	for i := 0; i < attempt; i++ {
+		delay *= 2
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay - p.jitter(delay)
+}
+
+// jitter returns the random part of the delay, up to the jitter fraction of it.
+func (p *RetryPolicy) jitter(delay time.Duration) time.Duration {
+	fraction := p.Jitter
+	if fraction <= 0 {
+		return 0
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	return time.Duration(float64(delay) * fraction * jitterRandom())
+}
+
+// RetryAfter returns the delay requested by the response's Retry-After header, which is
+// either a number of seconds or an HTTP date.
+func RetryAfter(response *http.Response) (time.Duration, bool) {
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
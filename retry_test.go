@@ -0,0 +1,142 @@
+package sfdc
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxRetries: 5,
+		Backoff:    time.Second,
+		MaxBackoff: 5 * time.Second,
+	}
+	tests := []struct {
+		name     string
+		attempt  int
+		response *http.Response
+		want     time.Duration
+	}{
+		{
+			name:     "first attempt",
+			attempt:  0,
+			response: &http.Response{Header: make(http.Header)},
+			want:     time.Second,
+		},
+		{
+			name:     "exponential",
+			attempt:  2,
+			response: &http.Response{Header: make(http.Header)},
+			want:     4 * time.Second,
+		},
+		{
+			name:     "capped",
+			attempt:  10,
+			response: &http.Response{Header: make(http.Header)},
+			want:     5 * time.Second,
+		},
+		{
+			name:    "retry after",
+			attempt: 2,
+			response: &http.Response{Header: http.Header{
+				"Retry-After": []string{"30"},
+			}},
+			want: 30 * time.Second,
+		},
+		{
+			name:    "invalid retry after",
+			attempt: 0,
+			response: &http.Response{Header: http.Header{
+				"Retry-After": []string{"soon"},
+			}},
+			want: time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, policy.Delay(tt.attempt, tt.response))
+		})
+	}
+}
+
+func TestRetryPolicy_Delay_Jitter(t *testing.T) {
+	random := jitterRandom
+	defer func() { jitterRandom = random }()
+	jitterRandom = func() float64 { return 0.5 }
+
+	tests := []struct {
+		name     string
+		policy   *RetryPolicy
+		attempt  int
+		response *http.Response
+		want     time.Duration
+	}{
+		{
+			name: "backoff",
+			policy: &RetryPolicy{
+				Backoff: 4 * time.Second,
+				Jitter:  0.5,
+			},
+			attempt:  0,
+			response: &http.Response{Header: make(http.Header)},
+			want:     3 * time.Second,
+		},
+		{
+			name: "capped backoff",
+			policy: &RetryPolicy{
+				Backoff:    time.Second,
+				MaxBackoff: 4 * time.Second,
+				Jitter:     0.5,
+			},
+			attempt:  10,
+			response: &http.Response{Header: make(http.Header)},
+			want:     3 * time.Second,
+		},
+		{
+			name: "retry after",
+			policy: &RetryPolicy{
+				Backoff: time.Second,
+				Jitter:  0.5,
+			},
+			attempt: 0,
+			response: &http.Response{Header: http.Header{
+				"Retry-After": []string{"4"},
+			}},
+			want: 5 * time.Second,
+		},
+		{
+			name: "fraction over one",
+			policy: &RetryPolicy{
+				Backoff: 4 * time.Second,
+				Jitter:  3,
+			},
+			attempt:  0,
+			response: &http.Response{Header: make(http.Header)},
+			want:     2 * time.Second,
+		},
+		{
+			name: "no jitter",
+			policy: &RetryPolicy{
+				Backoff: 4 * time.Second,
+			},
+			attempt:  0,
+			response: &http.Response{Header: make(http.Header)},
+			want:     4 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Delay(tt.attempt, tt.response))
+		})
+	}
+}
+
+func TestRetryPolicy_Retryable(t *testing.T) {
+	policy := &RetryPolicy{}
+	assert.True(t, policy.Retryable(&http.Response{StatusCode: http.StatusServiceUnavailable}))
+	assert.True(t, policy.Retryable(&http.Response{StatusCode: http.StatusTooManyRequests}))
+	assert.False(t, policy.Retryable(&http.Response{StatusCode: http.StatusBadRequest}))
+}
@@ -0,0 +1,64 @@
+package session
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/pkg/errors"
+)
+
+// Identity is the user and organization the session is authenticated as.
+type Identity struct {
+	ID             string `json:"id"`
+	UserID         string `json:"user_id"`
+	OrganizationID string `json:"organization_id"`
+	Username       string `json:"username"`
+	DisplayName    string `json:"display_name"`
+	NickName       string `json:"nick_name"`
+	Email          string `json:"email"`
+	UserType       string `json:"user_type"`
+	Language       string `json:"language"`
+	Locale         string `json:"locale"`
+	TimeZone       string `json:"timezone"`
+	Active         bool   `json:"active"`
+}
+
+// Identity will retrieve the identity of the session's user from the
+// identity URL returned with the authentication.
+func (s *Session) Identity() (*Identity, error) {
+	if err := s.Refresh(); err != nil {
+		return nil, errors.Wrap(err, "session refresh")
+	}
+
+	s.mu.RLock()
+	identityURL := s.response.ID
+	s.mu.RUnlock()
+	if identityURL == "" {
+		return nil, errors.New("session: authentication did not return an identity URL")
+	}
+
+	request, err := http.NewRequest(http.MethodGet, identityURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Accept", "application/json")
+	s.AuthorizationHeader(request)
+
+	response, err := s.Client().Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Wrap(sfdc.HandleError(response), "session identity")
+	}
+
+	var identity Identity
+	err = json.NewDecoder(response.Body).Decode(&identity)
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
@@ -13,3 +13,9 @@ func mockHTTPClient(fn roundTripFunc) *http.Client {
 		Transport: fn,
 	}
 }
+
+type errRoundTripFunc func(request *http.Request) (*http.Response, error)
+
+func (f errRoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,14 +18,23 @@ import (
 
 // Session is the authentication response.  This is used to generate the
 // authorization header for the Salesforce API calls.
+//
+// A Session is safe for concurrent use by multiple goroutines.  The token is
+// read and refreshed under a lock, so one session should be shared by all of
+// the resources.
 type Session struct {
 	// thread safe:
 	config sfdc.Configuration
+	client *http.Client
 
 	// thread unsafe:
 	mu        sync.RWMutex
 	response  *sessionPasswordResponse
 	expiresAt time.Time
+
+	usageMu  sync.Mutex
+	apiUsed  int
+	apiLimit int
 }
 
 // Clienter interface provides the HTTP client used by the
@@ -59,6 +71,9 @@ type ServiceFormatter interface {
 	ServiceURL() string
 }
 
+// AsyncServiceFormatter is the session interface that
+// formats the session for async service resources, like
+// the bulk 1.0 API.
 type AsyncServiceFormatter interface {
 	ServiceFormatter
 	// AsyncServiceURL will return the Salesforce instance for the async service URL.
@@ -77,6 +92,7 @@ type sessionPasswordResponse struct {
 const (
 	oauthEndpoint          = "/services/oauth2/token"
 	defaultSessionDuration = 24 * time.Hour
+	defaultRefreshMargin   = 5 * time.Minute
 )
 
 // Open is used to authenticate with Salesforce and open a session.  The user will need to
@@ -94,12 +110,20 @@ func Open(config sfdc.Configuration) (*Session, error) {
 	if config.SessionDuration == 0 {
 		config.SessionDuration = defaultSessionDuration
 	}
+	if config.RefreshMargin == 0 {
+		config.RefreshMargin = defaultRefreshMargin
+	}
 
 	session := &Session{
 		config: config,
 	}
+	client, err := newClient(session)
+	if err != nil {
+		return nil, err
+	}
+	session.client = client
 
-	err := session.refresh()
+	err = session.refresh()
 	if err != nil {
 		return nil, err
 	}
@@ -107,8 +131,8 @@ func Open(config sfdc.Configuration) (*Session, error) {
 	return session, nil
 }
 
-func passwordSessionRequest(creds *credentials.Credentials) (*http.Request, error) {
-	oauthURL := creds.URL() + oauthEndpoint
+func passwordSessionRequest(creds *credentials.Credentials, baseURL string) (*http.Request, error) {
+	oauthURL := baseURL + oauthEndpoint
 
 	body, err := creds.Retrieve()
 	if err != nil {
@@ -125,6 +149,15 @@ func passwordSessionRequest(creds *credentials.Credentials) (*http.Request, erro
 	return request, nil
 }
 
+func (s *Session) token(baseURL string) (*sessionPasswordResponse, error) {
+	req, err := passwordSessionRequest(s.config.Credentials, baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return passwordSessionResponse(req, s.Client())
+}
+
 func passwordSessionResponse(request *http.Request, client *http.Client) (*sessionPasswordResponse, error) {
 	response, err := client.Do(request)
 	if err != nil {
@@ -133,7 +166,7 @@ func passwordSessionResponse(request *http.Request, client *http.Client) (*sessi
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return nil, errors.Wrap(sfdc.HandleError(response), "session response")
+		return nil, errors.Wrap(sfdc.HandleAuthError(response), "session response")
 	}
 
 	var sessionResponse sessionPasswordResponse
@@ -187,9 +220,62 @@ func (s *Session) AuthorizationHeader(req *http.Request) {
 	req.Header.Add("Authorization", auth)
 }
 
-// Client returns the HTTP client to be used in APIs calls.
+// Client returns the HTTP client to be used in APIs calls.  The client
+// applies the session's default headers to every request.
 func (s *Session) Client() *http.Client {
-	return s.config.Client
+	if s.client == nil {
+		return s.config.Client
+	}
+	return s.client
+}
+
+// APIUsage returns the org's API requests used and the limit, from the
+// Sforce-Limit-Info header of the latest response that had it.  Both are
+// zero until a response has the header.
+func (s *Session) APIUsage() (used, limit int) {
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+
+	return s.apiUsed, s.apiLimit
+}
+
+func (s *Session) updateAPIUsage(response *http.Response) {
+	used, limit, ok := parseAPIUsage(response.Header.Get(limitInfoHeader))
+	if ok == false {
+		return
+	}
+
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+
+	s.apiUsed, s.apiLimit = used, limit
+}
+
+const limitInfoHeader = "Sforce-Limit-Info"
+
+// parseAPIUsage parses the api-usage of the limit info header, like
+// api-usage=18/5000.
+func parseAPIUsage(limitInfo string) (used, limit int, ok bool) {
+	for _, info := range strings.Split(limitInfo, ",") {
+		info = strings.TrimSpace(info)
+		if strings.HasPrefix(info, "api-usage=") == false {
+			continue
+		}
+		usage := strings.SplitN(strings.TrimPrefix(info, "api-usage="), "/", 2)
+		if len(usage) != 2 {
+			return 0, 0, false
+		}
+		used, err := strconv.Atoi(usage[0])
+		if err != nil {
+			return 0, 0, false
+		}
+		limit, err := strconv.Atoi(usage[1])
+		if err != nil {
+			return 0, 0, false
+		}
+		return used, limit, true
+	}
+	return 0, 0, false
 }
 
 // Refresh check if session is expired and refresh it if needed.
@@ -201,30 +287,111 @@ func (s *Session) Refresh() error {
 	return nil
 }
 
+// SkipRefresh returns the session formatter with a Refresh that does nothing, so
+// resources can be created with a token that is known to be valid without the
+// refresh that NewResource makes.  Only that eager refresh is skipped; when the
+// formatter is a *Session, its transport still refreshes the token before a
+// request once it expires within the refresh margin.
+func SkipRefresh(formatter ServiceFormatter) ServiceFormatter {
+	return &skipRefresh{
+		ServiceFormatter: formatter,
+	}
+}
+
+// AsyncSkipRefresh is SkipRefresh for async service formatters, like the ones
+// used by the bulk 1.0 resources.
+func AsyncSkipRefresh(formatter AsyncServiceFormatter) AsyncServiceFormatter {
+	return &asyncSkipRefresh{
+		AsyncServiceFormatter: formatter,
+	}
+}
+
+type skipRefresh struct {
+	ServiceFormatter
+}
+
+func (skipRefresh) Refresh() error {
+	return nil
+}
+
+type asyncSkipRefresh struct {
+	AsyncServiceFormatter
+}
+
+func (asyncSkipRefresh) Refresh() error {
+	return nil
+}
+
+// ExpiresAt returns when the access token expires, which is when it was issued
+// plus the configured session duration.
+func (s *Session) ExpiresAt() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.expiresAt
+}
+
+// isExpired returns true when the access token has expired, or will expire within
+// the refresh margin.
 func (s *Session) isExpired() bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return s.expiresAt.Before(time.Now().UTC())
+	return s.expiring()
+}
+
+func (s *Session) expiring() bool {
+	return s.expiresAt.Add(-s.config.RefreshMargin).Before(time.Now().UTC())
 }
 
-// refresh the session
+// issuedAt is when the access token was issued, from the milliseconds since the
+// epoch of the response.  If it is missing, or later than now because of clock
+// skew, now is used.
+func (r *sessionPasswordResponse) issuedAt(now time.Time) time.Time {
+	millis, err := strconv.ParseInt(r.IssuedAt, 10, 64)
+	if err != nil {
+		return now
+	}
+	issued := time.Unix(0, millis*int64(time.Millisecond)).UTC()
+	if issued.After(now) {
+		return now
+	}
+	return issued
+}
+
+// refresh the session.  The first session is opened with the login URL of the
+// credentials, like https://test.salesforce.com for a sandbox, and later sessions
+// are refreshed with the instance URL that was returned, since the org may not be
+// on the login host.  If the instance can not be reached, the login URL is used.
+//
+// The session is refreshed once when several resources find that it has expired
+// at the same time, since the others wait for the lock and find the refreshed
+// session.
 func (s *Session) refresh() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	req, err := passwordSessionRequest(s.config.Credentials)
-	if err != nil {
-		return err
+	if s.response != nil && s.expiring() == false {
+		return nil
 	}
 
-	resp, err := passwordSessionResponse(req, s.config.Client)
+	loginURL := s.config.Credentials.URL()
+	tokenURL := loginURL
+	if s.response != nil && s.response.InstanceURL != "" {
+		tokenURL = s.response.InstanceURL
+	}
+
+	resp, err := s.token(tokenURL)
+	var urlErr *url.Error
+	if err != nil && tokenURL != loginURL && errors.As(err, &urlErr) {
+		resp, err = s.token(loginURL)
+	}
 	if err != nil {
 		return err
 	}
 
 	s.response = resp
-	s.expiresAt = time.Now().Add(s.config.SessionDuration).UTC()
+	s.expiresAt = resp.issuedAt(time.Now().UTC()).Add(s.config.SessionDuration)
 
 	return nil
 }
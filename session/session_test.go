@@ -1,12 +1,18 @@
 package session
 
 import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -56,7 +62,7 @@ func Test_passwordSessionRequest(t *testing.T) {
 		if err != nil {
 			t.Fatal("password credentials can not return an error for these tests")
 		}
-		request, err := passwordSessionRequest(passwordCreds)
+		request, err := passwordSessionRequest(passwordCreds, scenario.creds.URL)
 
 		if err != nil && scenario.err == nil {
 			t.Errorf("%s Error was not expected %s", scenario.desc, err.Error())
@@ -147,7 +153,7 @@ func Test_passwordSessionResponse(t *testing.T) {
 					Header: make(http.Header),
 				}
 			}),
-			wantErr: fmt.Errorf(`session response: 400 Bad Request: {"error":"invalid_grant","error_description":"authentication failure"}`),
+			wantErr: fmt.Errorf(`session response: 400 Bad Request: invalid_grant: authentication failure`),
 		},
 		{
 			name: "ResponseDecodeError",
@@ -289,7 +295,7 @@ func TestNewPasswordSession(t *testing.T) {
 				}),
 				Version: 45,
 			},
-			wantErr: fmt.Errorf(`session response: 400 Bad Request: {"error":"invalid_grant","error_description":"authentication failure"}`),
+			wantErr: fmt.Errorf(`session response: 400 Bad Request: invalid_grant: authentication failure`),
 		},
 	}
 
@@ -489,6 +495,134 @@ func TestSession_isExpired(t *testing.T) {
 	}
 }
 
+func TestSession_isExpired_RefreshMargin(t *testing.T) {
+	s := &Session{
+		expiresAt: time.Now().Add(3 * time.Minute).UTC(),
+		config: sfdc.Configuration{
+			RefreshMargin: defaultRefreshMargin,
+		},
+	}
+	assert.True(t, s.isExpired())
+
+	s.expiresAt = time.Now().Add(time.Hour).UTC()
+	assert.False(t, s.isExpired())
+}
+
+func Test_sessionPasswordResponse_issuedAt(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		issuedAt string
+		want     time.Time
+	}{
+		"issued": {
+			issuedAt: "1577876400000",
+			want:     time.Date(2020, time.January, 1, 11, 0, 0, 0, time.UTC),
+		},
+		"missing": {
+			issuedAt: "",
+			want:     now,
+		},
+		"invalid": {
+			issuedAt: "yesterday",
+			want:     now,
+		},
+		"clock_skew": {
+			issuedAt: "1577883600000",
+			want:     now,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			response := &sessionPasswordResponse{IssuedAt: tt.issuedAt}
+			assert.Equal(t, tt.want, response.issuedAt(now))
+		})
+	}
+}
+
+func TestSession_ExpiresAt(t *testing.T) {
+	creds := testNewPasswordCredentials(t, credentials.PasswordCredentials{
+		URL:          "http://test.password.session",
+		Username:     "myusername",
+		Password:     "12345",
+		ClientID:     "some client id",
+		ClientSecret: "shhhh its a secret",
+	})
+	issued := time.Now().Add(-time.Hour).Truncate(time.Millisecond).UTC()
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		resp := fmt.Sprintf(`{"access_token":"token","instance_url":"https://some.salesforce.instance.com","token_type":"Bearer","issued_at":"%d"}`, issued.UnixNano()/int64(time.Millisecond))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(resp)),
+			Header:     make(http.Header),
+		}
+	})
+
+	session, err := Open(sfdc.Configuration{
+		Credentials:     creds,
+		Client:          client,
+		Version:         45,
+		SessionDuration: 2 * time.Hour,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, issued.Add(2*time.Hour), session.ExpiresAt())
+}
+
+func TestTransport_ProactiveRefresh(t *testing.T) {
+	creds := testNewPasswordCredentials(t, credentials.PasswordCredentials{
+		URL:          "http://test.password.session",
+		Username:     "myusername",
+		Password:     "12345",
+		ClientID:     "some client id",
+		ClientSecret: "shhhh its a secret",
+	})
+	tokens := 0
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		if req.URL.Path == oauthEndpoint {
+			tokens++
+			resp := fmt.Sprintf(`{"access_token":"token%d","instance_url":"https://some.salesforce.instance.com","token_type":"Bearer"}`, tokens)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(resp)),
+				Header:     make(http.Header),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(req.Header.Get("Authorization"))),
+			Header:     make(http.Header),
+		}
+	})
+	session, err := Open(sfdc.Configuration{
+		Credentials: creds,
+		Client:      client,
+		Version:     45,
+	})
+	require.NoError(t, err)
+
+	authorization := func() string {
+		request, err := http.NewRequest(http.MethodGet, session.ServiceURL()+"/limits", nil)
+		require.NoError(t, err)
+		session.AuthorizationHeader(request)
+		response, err := session.Client().Do(request)
+		require.NoError(t, err)
+		defer response.Body.Close()
+		body, err := ioutil.ReadAll(response.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	assert.Equal(t, "Bearer token1", authorization())
+	assert.Equal(t, 1, tokens)
+
+	session.mu.Lock()
+	session.expiresAt = time.Now().Add(time.Minute).UTC()
+	session.mu.Unlock()
+
+	assert.Equal(t, "Bearer token2", authorization())
+	assert.Equal(t, 2, tokens)
+}
+
 func TestSession_Refresh(t *testing.T) {
 	const (
 		oldToken = "oLd:ToKeN"
@@ -540,8 +674,107 @@ func TestSession_Refresh(t *testing.T) {
 		assert.Equal(t, oldToken, s.response.AccessToken)
 	})
 
+	t.Run("concurrent_expired", func(t *testing.T) {
+		var mu sync.Mutex
+		refreshes := 0
+		client := mockHTTPClient(func(req *http.Request) *http.Response {
+			mu.Lock()
+			refreshes++
+			mu.Unlock()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"access_token":"nEw:ToKeN"}`)),
+			}
+		})
+		s := &Session{
+			response:  response,
+			expiresAt: time.Now().Add(-1 * time.Minute).UTC(),
+			config: sfdc.Configuration{
+				SessionDuration: defaultSessionDuration,
+				Client:          client,
+				Credentials:     creds,
+			},
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, s.Refresh())
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, refreshes)
+		assert.Equal(t, newToken, s.response.AccessToken)
+	})
+
+	t.Run("instance_url", func(t *testing.T) {
+		var tokenURL string
+		client := mockHTTPClient(func(req *http.Request) *http.Response {
+			tokenURL = req.URL.String()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"access_token":"nEw:ToKeN","instance_url":"https://my.sandbox.salesforce.com"}`)),
+			}
+		})
+		s := &Session{
+			response: &sessionPasswordResponse{
+				AccessToken: oldToken,
+				InstanceURL: "https://my.sandbox.salesforce.com",
+			},
+			expiresAt: time.Now().Add(-1 * time.Minute).UTC(),
+			config: sfdc.Configuration{
+				SessionDuration: defaultSessionDuration,
+				Client:          client,
+				Credentials:     creds,
+			},
+		}
+
+		err := s.Refresh()
+		require.NoError(t, err)
+		assert.Equal(t, "https://my.sandbox.salesforce.com"+oauthEndpoint, tokenURL)
+		assert.Equal(t, newToken, s.response.AccessToken)
+	})
+
+	t.Run("instance_url_unreachable", func(t *testing.T) {
+		var tokenURLs []string
+		client := &http.Client{
+			Transport: errRoundTripFunc(func(req *http.Request) (*http.Response, error) {
+				tokenURLs = append(tokenURLs, req.URL.String())
+				if req.URL.Host == "my.sandbox.salesforce.com" {
+					return nil, errors.New("no such host")
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"access_token":"nEw:ToKeN"}`)),
+				}, nil
+			}),
+		}
+		s := &Session{
+			response: &sessionPasswordResponse{
+				AccessToken: oldToken,
+				InstanceURL: "https://my.sandbox.salesforce.com",
+			},
+			expiresAt: time.Now().Add(-1 * time.Minute).UTC(),
+			config: sfdc.Configuration{
+				SessionDuration: defaultSessionDuration,
+				Client:          client,
+				Credentials:     creds,
+			},
+		}
+
+		err := s.Refresh()
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"https://my.sandbox.salesforce.com" + oauthEndpoint,
+			"http://test.password.session" + oauthEndpoint,
+		}, tokenURLs)
+		assert.Equal(t, newToken, s.response.AccessToken)
+	})
+
 	t.Run("failed_to_refresh_expired", func(t *testing.T) {
-		const wantErr = `session response: 400 Bad Request: {"error":"invalid_grant","error_description":"authentication failure"}`
+		const wantErr = `session response: 400 Bad Request: invalid_grant: authentication failure`
 		client := mockHTTPClient(func(req *http.Request) *http.Response {
 			return &http.Response{
 				Status: "400 Bad Request",
@@ -561,5 +794,621 @@ func TestSession_Refresh(t *testing.T) {
 
 		err := s.Refresh()
 		assert.EqualError(t, err, wantErr)
+
+		var authErr *sfdc.AuthError
+		require.True(t, errors.As(err, &authErr))
+		assert.Equal(t, "invalid_grant", authErr.Code)
+		assert.Equal(t, "authentication failure", authErr.Description)
+	})
+}
+
+func TestSession_Concurrent(t *testing.T) {
+	creds := testNewPasswordCredentials(t, credentials.PasswordCredentials{
+		URL:          "http://test.password.session",
+		Username:     "myusername",
+		Password:     "12345",
+		ClientID:     "some client id",
+		ClientSecret: "shhhh its a secret",
+	})
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		resp := `{"access_token":"token","instance_url":"https://some.salesforce.instance.com","token_type":"Bearer"}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(resp)),
+			Header:     make(http.Header),
+		}
+	})
+	session, err := Open(sfdc.Configuration{
+		Credentials: creds,
+		Client:      client,
+		Version:     45,
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(expire bool) {
+			defer wg.Done()
+			if expire {
+				session.mu.Lock()
+				session.expiresAt = time.Now().UTC()
+				session.mu.Unlock()
+			}
+			request, err := http.NewRequest(http.MethodGet, session.ServiceURL()+"/limits", nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			session.AuthorizationHeader(request)
+			response, err := session.Client().Do(request)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			response.Body.Close()
+			assert.NoError(t, session.Refresh())
+			assert.Equal(t, "https://some.salesforce.instance.com", session.InstanceURL())
+		}(i%2 == 0)
+	}
+	wg.Wait()
+}
+
+func TestSkipRefresh(t *testing.T) {
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		t.Errorf("unexpected refresh request %s", req.URL.String())
+		return &http.Response{
+			Status: "400 Bad Request",
+			Body:   ioutil.NopCloser(strings.NewReader(`{"error":"invalid_grant"}`)),
+			Header: make(http.Header),
+		}
+	})
+	s := &Session{
+		response: &sessionPasswordResponse{
+			AccessToken: "token",
+			InstanceURL: "https://test.salesforce.com",
+		},
+		expiresAt: time.Now().Add(-1 * time.Minute).UTC(),
+		config: sfdc.Configuration{
+			Client:  client,
+			Version: 45,
+			Credentials: testNewPasswordCredentials(t, credentials.PasswordCredentials{
+				URL:          "http://test.password.session",
+				Username:     "myusername",
+				Password:     "12345",
+				ClientID:     "some client id",
+				ClientSecret: "shhhh its a secret",
+			}),
+		},
+	}
+
+	t.Run("service", func(t *testing.T) {
+		formatter := SkipRefresh(s)
+		require.NoError(t, formatter.Refresh())
+		assert.Equal(t, s.ServiceURL(), formatter.ServiceURL())
+	})
+
+	t.Run("async_service", func(t *testing.T) {
+		formatter := AsyncSkipRefresh(s)
+		require.NoError(t, formatter.Refresh())
+		assert.Equal(t, s.AsyncServiceURL(), formatter.AsyncServiceURL())
+	})
+}
+
+func TestSession_DefaultHeaders(t *testing.T) {
+	creds := testNewPasswordCredentials(t, credentials.PasswordCredentials{
+		URL:          "http://test.password.session",
+		Username:     "myusername",
+		Password:     "12345",
+		ClientID:     "some client id",
+		ClientSecret: "shhhh its a secret",
+	})
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		if req.URL.Path == oauthEndpoint {
+			resp := `{"access_token":"token","instance_url":"https://some.salesforce.instance.com","token_type":"Bearer"}`
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(resp)),
+				Header:     make(http.Header),
+			}
+		}
+		header, _ := json.Marshal(req.Header)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(string(header))),
+			Header:     make(http.Header),
+		}
+	})
+
+	session, err := Open(sfdc.Configuration{
+		Credentials: creds,
+		Client:      client,
+		Version:     45,
+		DefaultHeaders: map[string]string{
+			"Sforce-Call-Options": "client=go-sfdc",
+			"Accept":              "application/xml",
+		},
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodGet, session.ServiceURL()+"/limits", nil)
+	require.NoError(t, err)
+	request.Header.Add("Accept", "application/json")
+
+	response, err := session.Client().Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	var header http.Header
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&header))
+	assert.Equal(t, "client=go-sfdc", header.Get("Sforce-Call-Options"))
+	assert.Equal(t, "application/json", header.Get("Accept"))
+	assert.Empty(t, request.Header.Get("Sforce-Call-Options"))
+}
+
+func Test_baseTransport(t *testing.T) {
+	proxyURL, err := url.Parse("http://proxy.example.com:3128")
+	require.NoError(t, err)
+	tlsConfig := &tls.Config{ServerName: "example.my.salesforce.com"}
+	custom := roundTripFunc(func(req *http.Request) *http.Response { return nil })
+
+	t.Run("no_settings", func(t *testing.T) {
+		got, err := baseTransport(custom, sfdc.Configuration{})
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+	})
+
+	t.Run("proxy_and_tls", func(t *testing.T) {
+		got, err := baseTransport(nil, sfdc.Configuration{
+			ProxyURL:  proxyURL,
+			TLSConfig: tlsConfig,
+		})
+		require.NoError(t, err)
+		httpTransport, ok := got.(*http.Transport)
+		require.True(t, ok)
+		assert.NotSame(t, http.DefaultTransport, httpTransport)
+
+		request, err := http.NewRequest(http.MethodGet, "https://example.my.salesforce.com", nil)
+		require.NoError(t, err)
+		gotProxy, err := httpTransport.Proxy(request)
+		require.NoError(t, err)
+		assert.Equal(t, proxyURL, gotProxy)
+		assert.Equal(t, "example.my.salesforce.com", httpTransport.TLSClientConfig.ServerName)
+	})
+
+	t.Run("custom_transport", func(t *testing.T) {
+		_, err := baseTransport(custom, sfdc.Configuration{
+			ProxyURL: proxyURL,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestTransport_RetryAfter(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"state":"UploadComplete"}` {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if calls == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	session := &Session{
+		config: sfdc.Configuration{
+			Client: server.Client(),
+			RetryPolicy: &sfdc.RetryPolicy{
+				MaxRetries: 3,
+				Backoff:    time.Second,
+			},
+		},
+	}
+	client := &http.Client{
+		Transport: &transport{
+			base:    server.Client().Transport,
+			session: session,
+			sleep: func(ctx context.Context, delay time.Duration) error {
+				delays = append(delays, delay)
+				return nil
+			},
+		},
+	}
+
+	request, err := http.NewRequest(http.MethodPatch, server.URL, strings.NewReader(`{"state":"UploadComplete"}`))
+	require.NoError(t, err)
+
+	response, err := client.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []time.Duration{2 * time.Second}, delays)
+}
+
+func TestTransport_RetryExhausted(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	session := &Session{
+		config: sfdc.Configuration{
+			Client: server.Client(),
+			RetryPolicy: &sfdc.RetryPolicy{
+				MaxRetries: 2,
+				Backoff:    time.Second,
+			},
+		},
+	}
+	client := &http.Client{
+		Transport: &transport{
+			base:    server.Client().Transport,
+			session: session,
+			sleep: func(ctx context.Context, delay time.Duration) error {
+				delays = append(delays, delay)
+				return nil
+			},
+		},
+	}
+
+	response, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, delays)
+}
+
+func TestSession_Identity(t *testing.T) {
+	creds := testNewPasswordCredentials(t, credentials.PasswordCredentials{
+		URL:          "http://test.password.session",
+		Username:     "myusername",
+		Password:     "12345",
+		ClientID:     "some client id",
+		ClientSecret: "shhhh its a secret",
 	})
+	tests := []struct {
+		name    string
+		token   string
+		handler func(req *http.Request) *http.Response
+		want    *Identity
+		wantErr bool
+	}{
+		{
+			name:  "success",
+			token: `{"access_token":"token","instance_url":"https://some.salesforce.instance.com","id":"https://login.salesforce.com/id/00Dx0000000BV7z/005x00000012Q9P","token_type":"Bearer"}`,
+			handler: func(req *http.Request) *http.Response {
+				if req.URL.String() != "https://login.salesforce.com/id/00Dx0000000BV7z/005x00000012Q9P" || req.Header.Get("Authorization") != "Bearer token" {
+					return &http.Response{
+						StatusCode: http.StatusBadRequest,
+						Body:       ioutil.NopCloser(strings.NewReader(`[{"message":"bad request","errorCode":"BAD"}]`)),
+						Header:     make(http.Header),
+					}
+				}
+				resp := `{"id":"https://login.salesforce.com/id/00Dx0000000BV7z/005x00000012Q9P","user_id":"005x00000012Q9P","organization_id":"00Dx0000000BV7z","username":"admin@example.com","display_name":"Alan Van","active":true}`
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(resp)),
+					Header:     make(http.Header),
+				}
+			},
+			want: &Identity{
+				ID:             "https://login.salesforce.com/id/00Dx0000000BV7z/005x00000012Q9P",
+				UserID:         "005x00000012Q9P",
+				OrganizationID: "00Dx0000000BV7z",
+				Username:       "admin@example.com",
+				DisplayName:    "Alan Van",
+				Active:         true,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "no identity url",
+			token:   `{"access_token":"token","instance_url":"https://some.salesforce.instance.com","token_type":"Bearer"}`,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:  "error response",
+			token: `{"access_token":"token","instance_url":"https://some.salesforce.instance.com","id":"https://login.salesforce.com/id/00Dx0000000BV7z/005x00000012Q9P","token_type":"Bearer"}`,
+			handler: func(req *http.Request) *http.Response {
+				return &http.Response{
+					StatusCode: http.StatusForbidden,
+					Body:       ioutil.NopCloser(strings.NewReader(`[{"message":"Bad_OAuth_Token","errorCode":"FORBIDDEN"}]`)),
+					Header:     make(http.Header),
+				}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				if req.URL.Path == oauthEndpoint {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(tt.token)),
+						Header:     make(http.Header),
+					}
+				}
+				return tt.handler(req)
+			})
+			session, err := Open(sfdc.Configuration{
+				Credentials: creds,
+				Client:      client,
+				Version:     45,
+			})
+			require.NoError(t, err)
+
+			got, err := session.Identity()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type mockTracer struct {
+	attributes []map[string]string
+	statuses   []int
+}
+
+func (mock *mockTracer) StartRequest(request *http.Request, attributes map[string]string) sfdc.Span {
+	mock.attributes = append(mock.attributes, attributes)
+	return mock
+}
+
+func (mock *mockTracer) End(response *http.Response, err error) {
+	if err != nil {
+		mock.statuses = append(mock.statuses, 0)
+		return
+	}
+	mock.statuses = append(mock.statuses, response.StatusCode)
+}
+
+func TestTransport_Tracer(t *testing.T) {
+	creds := testNewPasswordCredentials(t, credentials.PasswordCredentials{
+		URL:          "http://test.password.session",
+		Username:     "myusername",
+		Password:     "12345",
+		ClientID:     "some client id",
+		ClientSecret: "shhhh its a secret",
+	})
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		if req.URL.Path == oauthEndpoint {
+			resp := `{"access_token":"token","instance_url":"https://some.salesforce.instance.com","token_type":"Bearer"}`
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(resp)),
+				Header:     make(http.Header),
+			}
+		}
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Header:     make(http.Header),
+		}
+	})
+
+	tracer := &mockTracer{}
+	session, err := Open(sfdc.Configuration{
+		Credentials: creds,
+		Client:      client,
+		Version:     45,
+		Tracer:      tracer,
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPut, session.ServiceURL()+"/jobs/ingest/1234/batches", nil)
+	require.NoError(t, err)
+	request = sfdc.WithTraceAttributes(request, map[string]string{
+		sfdc.TraceJobID: "1234",
+	})
+	response, err := session.Client().Do(request)
+	require.NoError(t, err)
+	response.Body.Close()
+
+	assert.Equal(t, []map[string]string{{}, {sfdc.TraceJobID: "1234"}}, tracer.attributes)
+	assert.Equal(t, []int{http.StatusOK, http.StatusCreated}, tracer.statuses)
+}
+
+func TestTransport_UserAgent(t *testing.T) {
+	creds := testNewPasswordCredentials(t, credentials.PasswordCredentials{
+		URL:          "http://test.password.session",
+		Username:     "myusername",
+		Password:     "12345",
+		ClientID:     "some client id",
+		ClientSecret: "shhhh its a secret",
+	})
+	tests := []struct {
+		name      string
+		userAgent string
+		header    string
+		want      string
+	}{
+		{
+			name: "default",
+			want: defaultUserAgent,
+		},
+		{
+			name:      "configured",
+			userAgent: "my-integration/2.1",
+			want:      "my-integration/2.1",
+		},
+		{
+			name:      "request header",
+			userAgent: "my-integration/2.1",
+			header:    "my-job/1.0",
+			want:      "my-job/1.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var userAgents []string
+			client := mockHTTPClient(func(req *http.Request) *http.Response {
+				userAgents = append(userAgents, req.Header.Get("User-Agent"))
+				resp := `{"access_token":"token","instance_url":"https://some.salesforce.instance.com","token_type":"Bearer"}`
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(resp)),
+					Header:     make(http.Header),
+				}
+			})
+			session, err := Open(sfdc.Configuration{
+				Credentials: creds,
+				Client:      client,
+				Version:     45,
+				UserAgent:   tt.userAgent,
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodGet, session.ServiceURL()+"/limits", nil)
+			require.NoError(t, err)
+			if tt.header != "" {
+				request.Header.Set("User-Agent", tt.header)
+			}
+			response, err := session.Client().Do(request)
+			require.NoError(t, err)
+			response.Body.Close()
+
+			require.Len(t, userAgents, 2)
+			assert.Equal(t, tt.want, userAgents[1])
+			assert.True(t, strings.HasPrefix(defaultUserAgent, "go-sfdc"))
+		})
+	}
+}
+
+func Test_parseAPIUsage(t *testing.T) {
+	tests := []struct {
+		name      string
+		limitInfo string
+		wantUsed  int
+		wantLimit int
+		wantOK    bool
+	}{
+		{
+			name:      "api usage",
+			limitInfo: "api-usage=18/5000",
+			wantUsed:  18,
+			wantLimit: 5000,
+			wantOK:    true,
+		},
+		{
+			name:      "per app usage",
+			limitInfo: "per-app-api-usage=17/250(appName=sample-connected-app), api-usage=25/15000",
+			wantUsed:  25,
+			wantLimit: 15000,
+			wantOK:    true,
+		},
+		{
+			name:      "missing",
+			limitInfo: "",
+			wantOK:    false,
+		},
+		{
+			name:      "malformed",
+			limitInfo: "api-usage=18",
+			wantOK:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			used, limit, ok := parseAPIUsage(tt.limitInfo)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantUsed, used)
+			assert.Equal(t, tt.wantLimit, limit)
+		})
+	}
+}
+
+func TestSession_APIUsage(t *testing.T) {
+	creds := testNewPasswordCredentials(t, credentials.PasswordCredentials{
+		URL:          "http://test.password.session",
+		Username:     "myusername",
+		Password:     "12345",
+		ClientID:     "some client id",
+		ClientSecret: "shhhh its a secret",
+	})
+	limitInfo := ""
+	client := mockHTTPClient(func(req *http.Request) *http.Response {
+		resp := `{"access_token":"token","instance_url":"https://some.salesforce.instance.com","token_type":"Bearer"}`
+		header := make(http.Header)
+		if limitInfo != "" {
+			header.Set("Sforce-Limit-Info", limitInfo)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(strings.NewReader(resp)),
+			Header:     header,
+		}
+	})
+	session, err := Open(sfdc.Configuration{
+		Credentials: creds,
+		Client:      client,
+		Version:     45,
+	})
+	require.NoError(t, err)
+
+	used, limit := session.APIUsage()
+	assert.Equal(t, 0, used)
+	assert.Equal(t, 0, limit)
+
+	for _, info := range []string{"api-usage=10/15000", "", "api-usage=11/15000"} {
+		limitInfo = info
+		response, err := session.Client().Get(session.ServiceURL() + "/limits")
+		require.NoError(t, err)
+		response.Body.Close()
+	}
+
+	used, limit = session.APIUsage()
+	assert.Equal(t, 11, used)
+	assert.Equal(t, 15000, limit)
+}
+
+func TestRateLimiter_wait(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var delays []time.Duration
+	limiter := newRateLimiter(2)
+	limiter.last = now
+	limiter.now = func() time.Time {
+		return now
+	}
+	limiter.sleep = func(ctx context.Context, delay time.Duration) error {
+		delays = append(delays, delay)
+		return ctx.Err()
+	}
+
+	// the burst is allowed, then requests wait for the refill
+	for i := 0; i < 4; i++ {
+		require.NoError(t, limiter.wait(context.Background()))
+	}
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, time.Second}, delays)
+
+	// a cancelled wait gives back its token
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, limiter.wait(ctx))
+
+	now = now.Add(2 * time.Second)
+	delays = nil
+	require.NoError(t, limiter.wait(context.Background()))
+	assert.Empty(t, delays)
 }
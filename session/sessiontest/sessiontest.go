@@ -0,0 +1,171 @@
+// Package sessiontest provides a fake session for testing code that uses the
+// go-sfdc resources.  The fake session sends the requests to a local test
+// server that returns canned responses.
+package sessiontest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/enrique-esquivel/go-sfdc/session"
+)
+
+// DefaultVersion is the Salesforce API version of a fake session.
+const DefaultVersion = 45
+
+// AccessToken is the token the fake session adds to the authorization header.
+const AccessToken = "00Dsessiontest"
+
+var _ session.AsyncServiceFormatter = (*Session)(nil)
+
+// Session is a fake session that implements the session formatter interfaces.
+// The requests made with the session's client are sent to the test server.
+//
+// RefreshErr is returned by Refresh, which can be used to test session failures.
+type Session struct {
+	Server     *httptest.Server
+	APIVersion int
+	RefreshErr error
+}
+
+// NewSession starts a test server with the handler and returns a fake session for
+// it.  The session should be closed when the test is done.
+func NewSession(handler http.Handler) *Session {
+	return &Session{
+		Server:     httptest.NewServer(handler),
+		APIVersion: DefaultVersion,
+	}
+}
+
+// Close shuts down the test server.
+func (s *Session) Close() {
+	s.Server.Close()
+}
+
+// InstanceURL returns the URL of the test server.
+func (s *Session) InstanceURL() string {
+	return s.Server.URL
+}
+
+// Version returns the Salesforce API version of the session.
+func (s *Session) Version() int {
+	return s.APIVersion
+}
+
+// ServiceURL returns the service URL on the test server.
+func (s *Session) ServiceURL() string {
+	return fmt.Sprintf("%s/services/data/v%d.0", s.Server.URL, s.APIVersion)
+}
+
+// AsyncServiceURL returns the async service URL on the test server.
+func (s *Session) AsyncServiceURL() string {
+	return fmt.Sprintf("%s/services/async/v%d.0", s.Server.URL, s.APIVersion)
+}
+
+// AuthorizationHeader adds the fake access token to the request.
+func (s *Session) AuthorizationHeader(request *http.Request) {
+	request.Header.Add("Authorization", "Bearer "+AccessToken)
+}
+
+// Client returns the test server's client.
+func (s *Session) Client() *http.Client {
+	return s.Server.Client()
+}
+
+// Refresh returns the session's RefreshErr.
+func (s *Session) Refresh() error {
+	return s.RefreshErr
+}
+
+// Response is a canned response.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+}
+
+// Responses is a handler of canned responses.  The responses are keyed by the
+// request method and path, like "GET /services/data/v45.0/limits".  Requests
+// without a canned response get a Salesforce NOT_FOUND error.  The requests
+// that are handled are recorded.
+type Responses struct {
+	responses map[string]Response
+	mu        sync.Mutex
+	requests  []Request
+}
+
+// Request is a request received by the canned responses.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   string
+}
+
+// NewResponses creates a handler with the canned responses.
+func NewResponses(responses map[string]Response) *Responses {
+	canned := make(map[string]Response, len(responses))
+	for key, response := range responses {
+		canned[key] = response
+	}
+	return &Responses{
+		responses: canned,
+	}
+}
+
+// Set will add, or replace, the canned response for the method and path.
+func (r *Responses) Set(method, path string, response Response) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.responses[method+" "+path] = response
+}
+
+// Requests returns the requests that have been received.
+func (r *Responses) Requests() []Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Request(nil), r.requests...)
+}
+
+// ServeHTTP writes the canned response of the request.
+func (r *Responses) ServeHTTP(w http.ResponseWriter, request *http.Request) {
+	var body strings.Builder
+	io.Copy(&body, request.Body)
+
+	r.mu.Lock()
+	r.requests = append(r.requests, Request{
+		Method: request.Method,
+		Path:   request.URL.Path,
+		Query:  request.URL.RawQuery,
+		Header: request.Header.Clone(),
+		Body:   body.String(),
+	})
+	response, ok := r.responses[request.Method+" "+request.URL.Path]
+	r.mu.Unlock()
+
+	if ok == false {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `[{"errorCode":"NOT_FOUND","message":"sessiontest: no response for %s %s"}]`, request.Method, request.URL.Path)
+		return
+	}
+
+	for header, values := range response.Header {
+		for _, value := range values {
+			w.Header().Add(header, value)
+		}
+	}
+	statusCode := response.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.WriteHeader(statusCode)
+	io.WriteString(w, response.Body)
+}
@@ -0,0 +1,71 @@
+package sessiontest
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/enrique-esquivel/go-sfdc/soql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_URLs(t *testing.T) {
+	session := NewSession(http.NotFoundHandler())
+	defer session.Close()
+
+	assert.Equal(t, session.Server.URL, session.InstanceURL())
+	assert.Equal(t, session.Server.URL+"/services/data/v45.0", session.ServiceURL())
+	assert.Equal(t, session.Server.URL+"/services/async/v45.0", session.AsyncServiceURL())
+	assert.Equal(t, DefaultVersion, session.Version())
+	assert.NoError(t, session.Refresh())
+
+	session.RefreshErr = errors.New("expired")
+	assert.EqualError(t, session.Refresh(), "expired")
+}
+
+func TestResponses(t *testing.T) {
+	responses := NewResponses(map[string]Response{
+		"GET /services/data/v45.0/query/": {
+			Body: `{"done":true,"totalSize":1,"records":[{"attributes":{"type":"Account"},"Name":"Acme"}]}`,
+		},
+	})
+	session := NewSession(responses)
+	defer session.Close()
+
+	resource, err := soql.NewResource(session)
+	require.NoError(t, err)
+
+	querier, err := soql.NewQuery(soql.QueryInput{
+		ObjectType: "Account",
+		FieldList:  []string{"Name"},
+	})
+	require.NoError(t, err)
+
+	result, err := resource.Query(querier, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.TotalSize())
+	assert.Equal(t, "Acme", result.Records()[0].Record().Fields()["Name"])
+
+	requests := responses.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "q=SELECT+Name+FROM+Account", requests[0].Query)
+	assert.Equal(t, "Bearer "+AccessToken, requests[0].Header.Get("Authorization"))
+
+	responses.Set(http.MethodGet, "/services/data/v45.0/query/", Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       `[{"errorCode":"MALFORMED_QUERY","message":"unexpected token"}]`,
+	})
+	_, err = resource.Query(querier, false)
+	assert.Error(t, err)
+
+	response, err := session.Client().Get(session.ServiceURL() + "/limits")
+	require.NoError(t, err)
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	assert.True(t, strings.Contains(string(body), "NOT_FOUND"))
+}
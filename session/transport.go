@@ -0,0 +1,192 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/pkg/errors"
+)
+
+// transport is the round tripper used by the session's HTTP client.  It
+// applies the session wide settings to every request made by the resources.
+type transport struct {
+	base    http.RoundTripper
+	session *Session
+	limiter *rateLimiter
+	sleep   func(context.Context, time.Duration) error
+}
+
+const modulePath = "github.com/enrique-esquivel/go-sfdc"
+
+// defaultUserAgent is go-sfdc with the version of the module, when it is known
+// from the build information.
+var defaultUserAgent = func() string {
+	userAgent := "go-sfdc"
+	info, ok := debug.ReadBuildInfo()
+	if ok == false {
+		return userAgent
+	}
+	modules := append([]*debug.Module{&info.Main}, info.Deps...)
+	for _, module := range modules {
+		if module.Path == modulePath && module.Version != "" && module.Version != "(devel)" {
+			return userAgent + "/" + module.Version
+		}
+	}
+	return userAgent
+}()
+
+func newClient(session *Session) (*http.Client, error) {
+	client := *session.config.Client
+	base, err := baseTransport(client.Transport, session.config)
+	if err != nil {
+		return nil, err
+	}
+	var limiter *rateLimiter
+	if session.config.RateLimit > 0 {
+		limiter = newRateLimiter(session.config.RateLimit)
+	}
+	client.Transport = &transport{
+		base:    base,
+		session: session,
+		limiter: limiter,
+		sleep:   sleep,
+	}
+	return &client, nil
+}
+
+func baseTransport(base http.RoundTripper, config sfdc.Configuration) (http.RoundTripper, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if config.ProxyURL == nil && config.TLSConfig == nil {
+		return base, nil
+	}
+
+	httpTransport, ok := base.(*http.Transport)
+	if ok == false {
+		return nil, errors.New("session: proxy and TLS configuration require the client transport to be a *http.Transport")
+	}
+	httpTransport = httpTransport.Clone()
+	if config.ProxyURL != nil {
+		httpTransport.Proxy = http.ProxyURL(config.ProxyURL)
+	}
+	if config.TLSConfig != nil {
+		httpTransport.TLSClientConfig = config.TLSConfig.Clone()
+	}
+	return httpTransport, nil
+}
+
+// RoundTrip executes the HTTP request with the session settings.
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	if err := t.refresh(request); err != nil {
+		return nil, err
+	}
+	for header, value := range t.session.config.DefaultHeaders {
+		if request.Header.Get(header) == "" {
+			request.Header.Set(header, value)
+		}
+	}
+	if request.Header.Get("User-Agent") == "" {
+		userAgent := t.session.config.UserAgent
+		if userAgent == "" {
+			userAgent = defaultUserAgent
+		}
+		request.Header.Set("User-Agent", userAgent)
+	}
+
+	var span sfdc.Span
+	if tracer := t.session.config.Tracer; tracer != nil {
+		span = tracer.StartRequest(request, sfdc.TraceAttributes(request))
+	}
+	response, err := t.roundTrip(request)
+	if err == nil {
+		t.session.updateAPIUsage(response)
+	}
+	if span != nil {
+		span.End(response, err)
+	}
+	return response, err
+}
+
+// refresh the session before a request with its access token is sent, when the
+// token expires within the refresh margin, and replace the request's token.  The
+// token requests do not have an access token, so they are sent as is.
+func (t *transport) refresh(request *http.Request) error {
+	if request.Header.Get("Authorization") == "" || t.session.isExpired() == false {
+		return nil
+	}
+	if err := t.session.Refresh(); err != nil {
+		return err
+	}
+	request.Header.Del("Authorization")
+	t.session.AuthorizationHeader(request)
+	return nil
+}
+
+func (t *transport) roundTrip(request *http.Request) (*http.Response, error) {
+	policy := t.session.config.RetryPolicy
+	for attempt := 0; ; attempt++ {
+		if t.limiter != nil {
+			if err := t.limiter.wait(request.Context()); err != nil {
+				return nil, err
+			}
+		}
+		response, err := t.base.RoundTrip(request)
+		if err != nil {
+			return nil, err
+		}
+		if policy == nil || attempt >= policy.MaxRetries || policy.Retryable(response) == false {
+			return response, nil
+		}
+
+		retry, err := replay(request)
+		if err != nil {
+			response.Body.Close()
+			return nil, err
+		}
+		if retry == nil {
+			return response, nil
+		}
+		delay := policy.Delay(attempt, response)
+		response.Body.Close()
+
+		if err := t.sleep(request.Context(), delay); err != nil {
+			return nil, err
+		}
+		request = retry
+	}
+}
+
+// replay returns a copy of the request that can be sent again, or nil if
+// the request body can not be replayed.
+func replay(request *http.Request) (*http.Request, error) {
+	retry := request.Clone(request.Context())
+	if request.Body == nil || request.Body == http.NoBody {
+		return retry, nil
+	}
+	if request.GetBody == nil {
+		return nil, nil
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retry.Body = body
+	return retry, nil
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,157 @@
+package sobject
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/enrique-esquivel/go-sfdc/session"
+)
+
+type blob struct {
+	session session.ServiceFormatter
+}
+
+func (b *blob) downloadCallout(sobject, id, field string, w io.Writer) error {
+	request, err := b.downloadRequest(sobject, id, field)
+	if err != nil {
+		return err
+	}
+
+	return b.downloadResponse(request, w)
+}
+func (b *blob) downloadRequest(sobject, id, field string) (*http.Request, error) {
+	blobURL := b.session.ServiceURL() + objectEndpoint + sobject + "/" + id + "/" + field
+
+	request, err := http.NewRequest(http.MethodGet, blobURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	b.session.AuthorizationHeader(request)
+	return request, nil
+}
+func (b *blob) downloadResponse(request *http.Request, w io.Writer) error {
+	response, err := b.session.Client().Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return sfdc.HandleError(response)
+	}
+
+	_, err = io.Copy(w, response.Body)
+	return err
+}
+
+func (b *blob) insertCallout(sobject string, fields map[string]interface{}, blobField string, data io.Reader) (string, error) {
+	request, err := b.insertRequest(sobject, fields, blobField, data)
+	if err != nil {
+		return "", err
+	}
+
+	return b.insertResponse(request)
+}
+
+// insertRequest streams the multipart body, which is the JSON record followed by
+// the binary data, so the data is not read into memory.
+func (b *blob) insertRequest(sobject string, fields map[string]interface{}, blobField string, data io.Reader) (*http.Request, error) {
+	record, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		writer.CloseWithError(writeBlobForm(form, sobject, record, blobField, blobFilename(fields, blobField), data))
+	}()
+
+	blobURL := b.session.ServiceURL() + objectEndpoint + sobject + "/"
+	request, err := http.NewRequest(http.MethodPost, blobURL, reader)
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+
+	request.Header.Add("Accept", "application/json")
+	request.Header.Add("Content-Type", form.FormDataContentType())
+	b.session.AuthorizationHeader(request)
+	return request, nil
+}
+func (b *blob) insertResponse(request *http.Request) (string, error) {
+	defer request.Body.Close()
+
+	response, err := b.session.Client().Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusCreated {
+		return "", sfdc.HandleError(response)
+	}
+
+	var value InsertValue
+	err = json.NewDecoder(response.Body).Decode(&value)
+	if err != nil {
+		return "", err
+	}
+	if err := value.Err(); err != nil {
+		return "", fmt.Errorf("insert blob response err: %w", err)
+	}
+	return value.ID, nil
+}
+
+func writeBlobForm(form *multipart.Writer, sobject string, record []byte, blobField, filename string, data io.Reader) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, blobEntity(sobject)))
+	header.Set("Content-Type", "application/json")
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if _, err = part.Write(record); err != nil {
+		return err
+	}
+
+	header = make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, blobField, filename))
+	header.Set("Content-Type", "application/octet-stream")
+	part, err = form.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(part, data); err != nil {
+		return err
+	}
+	return form.Close()
+}
+
+// blobEntity is the name of the record's part, which is entity_content for a
+// ContentVersion and entity_ with the lower case SObject otherwise, like
+// entity_document.
+func blobEntity(sobject string) string {
+	if sobject == "ContentVersion" {
+		return "entity_content"
+	}
+	return "entity_" + strings.ToLower(sobject)
+}
+
+// blobFilename is the file name of the binary part, which is the PathOnClient of
+// a ContentVersion or the Name of an Attachment or Document.
+func blobFilename(fields map[string]interface{}, blobField string) string {
+	for _, field := range []string{"PathOnClient", "Name"} {
+		if name, ok := fields[field].(string); ok && name != "" {
+			return strings.ReplaceAll(name, `"`, "")
+		}
+	}
+	return blobField
+}
@@ -0,0 +1,218 @@
+package sobject
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/enrique-esquivel/go-sfdc/session"
+)
+
+func Test_blob_Download(t *testing.T) {
+	type fields struct {
+		session session.ServiceFormatter
+	}
+	type args struct {
+		sobject string
+		id      string
+		field   string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Request Error",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "123://wrong",
+				},
+			},
+			args: args{
+				sobject: "ContentVersion",
+				id:      "068D00000000pgOIAQ",
+				field:   "VersionData",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Response HTTP Error",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						resp := `[{"message":"The requested resource does not exist","errorCode":"NOT_FOUND"}]`
+						return &http.Response{
+							StatusCode: http.StatusNotFound,
+							Status:     "404 Not Found",
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			args: args{
+				sobject: "ContentVersion",
+				id:      "068D00000000pgOIAQ",
+				field:   "VersionData",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Response Passing",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.String() != "https://test.salesforce.com/sobjects/ContentVersion/068D00000000pgOIAQ/VersionData" {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Bad URL: " + req.URL.String(),
+								Body:       ioutil.NopCloser(strings.NewReader("resp")),
+								Header:     make(http.Header),
+							}
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       ioutil.NopCloser(strings.NewReader("This is the version data")),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			args: args{
+				sobject: "ContentVersion",
+				id:      "068D00000000pgOIAQ",
+				field:   "VersionData",
+			},
+			want:    "This is the version data",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &blob{
+				session: tt.fields.session,
+			}
+			var buf bytes.Buffer
+			err := b.downloadCallout(tt.args.sobject, tt.args.id, tt.args.field, &buf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("blob.downloadCallout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if buf.String() != tt.want {
+				t.Errorf("blob.downloadCallout() = %v, want %v", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_blob_Insert(t *testing.T) {
+	type args struct {
+		sobject   string
+		fields    map[string]interface{}
+		blobField string
+		data      string
+	}
+	tests := []struct {
+		name    string
+		client  *http.Client
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Response Passing",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				fail := func(status string) *http.Response {
+					return &http.Response{
+						StatusCode: 500,
+						Status:     status,
+						Body:       ioutil.NopCloser(strings.NewReader("resp")),
+						Header:     make(http.Header),
+					}
+				}
+				if req.URL.String() != "https://test.salesforce.com/sobjects/ContentVersion/" || req.Method != http.MethodPost {
+					return fail("Bad URL: " + req.URL.String())
+				}
+				reader, err := req.MultipartReader()
+				if err != nil {
+					return fail(err.Error())
+				}
+				part, err := reader.NextPart()
+				if err != nil || part.FormName() != "entity_content" || part.Header.Get("Content-Type") != "application/json" {
+					return fail("Bad entity part")
+				}
+				record, _ := ioutil.ReadAll(part)
+				if string(record) != `{"PathOnClient":"report.pdf","Title":"Report"}` {
+					return fail("Bad record: " + string(record))
+				}
+				part, err = reader.NextPart()
+				if err != nil || part.FormName() != "VersionData" || part.FileName() != "report.pdf" {
+					return fail("Bad data part")
+				}
+				data, _ := ioutil.ReadAll(part)
+				if string(data) != "%PDF-1.4" {
+					return fail("Bad data: " + string(data))
+				}
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"id":"068D00000000pgOIAQ","success":true,"errors":[]}`)),
+					Header:     make(http.Header),
+				}
+			}),
+			args: args{
+				sobject: "ContentVersion",
+				fields: map[string]interface{}{
+					"Title":        "Report",
+					"PathOnClient": "report.pdf",
+				},
+				blobField: "VersionData",
+				data:      "%PDF-1.4",
+			},
+			want:    "068D00000000pgOIAQ",
+			wantErr: false,
+		},
+		{
+			name: "Response HTTP Error",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				resp := `[{"message":"Required fields are missing: [PathOnClient]","errorCode":"REQUIRED_FIELD_MISSING"}]`
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Status:     "400 Bad Request",
+					Body:       ioutil.NopCloser(strings.NewReader(resp)),
+					Header:     make(http.Header),
+				}
+			}),
+			args: args{
+				sobject:   "ContentVersion",
+				blobField: "VersionData",
+				data:      "%PDF-1.4",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &blob{
+				session: &mockSessionFormatter{
+					url:    "https://test.salesforce.com",
+					client: tt.client,
+				},
+			}
+			got, err := b.insertCallout(tt.args.sobject, tt.args.fields, tt.args.blobField, strings.NewReader(tt.args.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("blob.insertCallout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("blob.insertCallout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
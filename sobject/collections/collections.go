@@ -35,11 +35,16 @@ type collection struct {
 }
 
 // Resource is the structure for the SObject Collections API.
+//
+// Insert, Update, Upsert and Delete honor allOrNone.  When allOrNone is true, the
+// records are rolled back if any record fails, otherwise the records that succeed
+// are committed and each value reports its own errors.
 type Resource struct {
 	update *update
 	query  *query
 	insert *insert
 	remove *remove
+	upsert *upsert
 }
 
 // NewResources forms the Salesforce SObject Collections resource structure.  The
@@ -68,6 +73,9 @@ func NewResources(session session.ServiceFormatter) (*Resource, error) {
 		remove: &remove{
 			session: session,
 		},
+		upsert: &upsert{
+			session: session,
+		},
 	}, nil
 }
 
@@ -107,6 +115,31 @@ func (r *Resource) Update(allOrNone bool, records []sobject.Updater) ([]UpdateVa
 	return r.update.callout(allOrNone, records)
 }
 
+// Upsert will insert or update a group of records in the Salesforce org, matched on the
+// external ID field.  The records must be the same SObject and have the same external ID
+// field.  It is the responsibility of the caller to properly chunck the records.
+func (r *Resource) Upsert(allOrNone bool, records []sobject.Upserter) ([]sobject.UpsertValue, error) {
+	if r.upsert == nil {
+		return nil, errors.New("collections resource: collections may not have been initialized properly")
+	}
+	if len(records) == 0 {
+		return nil, errors.New("collections resource: upsert records can not be empty")
+	}
+
+	sobjectName := records[0].SObject()
+	externalField := records[0].ExternalField()
+	for _, record := range records {
+		if record.SObject() != sobjectName {
+			return nil, fmt.Errorf("collections resource: upsert records must be the same sobject: %s, %s", sobjectName, record.SObject())
+		}
+		if record.ExternalField() != externalField {
+			return nil, fmt.Errorf("collections resource: upsert records must have the same external field: %s, %s", externalField, record.ExternalField())
+		}
+	}
+
+	return r.upsert.callout(allOrNone, sobjectName, externalField, records)
+}
+
 // Query will retrieve a group of records from the Salesforce org.  The records to retrieve must
 // be the same SObject.
 func (r *Resource) Query(sobject string, records []sobject.Querier) ([]*sfdc.Record, error) {
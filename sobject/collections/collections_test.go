@@ -231,6 +231,11 @@ func TestNewResource(t *testing.T) {
 						url: "some.url.com",
 					},
 				},
+				upsert: &upsert{
+					session: &mockSessionFormatter{
+						url: "some.url.com",
+					},
+				},
 			},
 			wantErr: false,
 		},
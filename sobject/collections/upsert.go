@@ -0,0 +1,48 @@
+package collections
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/enrique-esquivel/go-sfdc/session"
+	"github.com/enrique-esquivel/go-sfdc/sobject"
+)
+
+type upsert struct {
+	session session.ServiceFormatter
+}
+
+func (u *upsert) callout(allOrNone bool, sobjectName, externalField string, records []sobject.Upserter) ([]sobject.UpsertValue, error) {
+	payload, err := u.payload(allOrNone, records)
+	if err != nil {
+		return nil, err
+	}
+	c := &collection{
+		method:      http.MethodPatch,
+		body:        payload,
+		endpoint:    endpoint + "/" + sobjectName + "/" + externalField,
+		contentType: jsonContentType,
+	}
+	var values []sobject.UpsertValue
+	err = c.send(u.session, &values)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+func (u *upsert) payload(allOrNone bool, recs []sobject.Upserter) (*bytes.Reader, error) {
+	records := make([]interface{}, len(recs))
+	for idx, upserter := range recs {
+		rec := map[string]interface{}{
+			"attributes": map[string]string{
+				"type": upserter.SObject(),
+			},
+		}
+		for field, value := range upserter.Fields() {
+			rec[field] = value
+		}
+		rec[upserter.ExternalField()] = upserter.ID()
+		records[idx] = rec
+	}
+	return dmlpayload(allOrNone, records)
+}
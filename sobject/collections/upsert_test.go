@@ -0,0 +1,271 @@
+package collections
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/enrique-esquivel/go-sfdc/session"
+	"github.com/enrique-esquivel/go-sfdc/sobject"
+)
+
+type mockUpserter struct {
+	mockUpdater
+	externalField string
+}
+
+func (mock *mockUpserter) ExternalField() string {
+	return mock.externalField
+}
+
+func TestUpsert_payload(t *testing.T) {
+	type args struct {
+		allOrNone bool
+		records   []sobject.Upserter
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "payloading",
+			args: args{
+				allOrNone: true,
+				records: []sobject.Upserter{
+					&mockUpserter{
+						mockUpdater: mockUpdater{
+							sobject: "Account",
+							fields: map[string]interface{}{
+								"Name": "Upsert",
+							},
+							id: "AC-1001",
+						},
+						externalField: "External_Id__c",
+					},
+				},
+			},
+			want: map[string]interface{}{
+				"allOrNone": true,
+				"records": []interface{}{
+					map[string]interface{}{
+						"attributes": map[string]interface{}{
+							"type": "Account",
+						},
+						"Name":           "Upsert",
+						"External_Id__c": "AC-1001",
+					},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &upsert{}
+			got, err := u.payload(tt.args.allOrNone, tt.args.records)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Upsert.payload() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var payload map[string]interface{}
+			if err := json.NewDecoder(got).Decode(&payload); err != nil {
+				t.Errorf("Upsert.payload() decode error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(payload, tt.want) {
+				t.Errorf("Upsert.payload() = %v, want %v", payload, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpsert_Callout(t *testing.T) {
+	type fields struct {
+		session session.ServiceFormatter
+	}
+	type args struct {
+		allOrNone     bool
+		sobjectName   string
+		externalField string
+		records       []sobject.Upserter
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []sobject.UpsertValue
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "something.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+
+						if req.URL.String() != "something.com/composite/sobjects/Account/External_Id__c" {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Bad URL: " + req.URL.String(),
+								Body:       ioutil.NopCloser(strings.NewReader("resp")),
+								Header:     make(http.Header),
+							}
+						}
+
+						if req.Method != http.MethodPatch {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Bad Method",
+								Body:       ioutil.NopCloser(strings.NewReader("resp")),
+								Header:     make(http.Header),
+							}
+						}
+
+						resp := `
+						[
+							{
+							   "id" : "001RM000003oLnnYAE",
+							   "success" : true,
+							   "errors" : [ ],
+							   "created" : true
+							},
+							{
+							   "id" : "001RM000003oLruYAE",
+							   "success" : true,
+							   "errors" : [ ],
+							   "created" : false
+							}
+						 ]`
+
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "Some Status",
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			args: args{
+				allOrNone:     true,
+				sobjectName:   "Account",
+				externalField: "External_Id__c",
+				records: []sobject.Upserter{
+					&mockUpserter{
+						mockUpdater: mockUpdater{
+							sobject: "Account",
+							fields: map[string]interface{}{
+								"Name": "Upsert One",
+							},
+							id: "AC-1001",
+						},
+						externalField: "External_Id__c",
+					},
+					&mockUpserter{
+						mockUpdater: mockUpdater{
+							sobject: "Account",
+							fields: map[string]interface{}{
+								"Name": "Upsert Two",
+							},
+							id: "AC-1002",
+						},
+						externalField: "External_Id__c",
+					},
+				},
+			},
+			want: []sobject.UpsertValue{
+				{
+					Created: true,
+					InsertValue: sobject.InsertValue{
+						Success: true,
+						ID:      "001RM000003oLnnYAE",
+						Errors:  make([]sfdc.Error, 0),
+					},
+				},
+				{
+					Created: false,
+					InsertValue: sobject.InsertValue{
+						Success: true,
+						ID:      "001RM000003oLruYAE",
+						Errors:  make([]sfdc.Error, 0),
+					},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &upsert{
+				session: tt.fields.session,
+			}
+			got, err := u.callout(tt.args.allOrNone, tt.args.sobjectName, tt.args.externalField, tt.args.records)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Upsert.Callout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Upsert.Callout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResource_Upsert(t *testing.T) {
+	account := func(externalField string) sobject.Upserter {
+		return &mockUpserter{
+			mockUpdater:   mockUpdater{sobject: "Account"},
+			externalField: externalField,
+		}
+	}
+	contact := &mockUpserter{
+		mockUpdater:   mockUpdater{sobject: "Contact"},
+		externalField: "External_Id__c",
+	}
+	tests := []struct {
+		name    string
+		upsert  *upsert
+		records []sobject.Upserter
+		wantErr bool
+	}{
+		{
+			name:    "No upsert",
+			records: []sobject.Upserter{account("External_Id__c")},
+			wantErr: true,
+		},
+		{
+			name:    "No records",
+			upsert:  &upsert{},
+			wantErr: true,
+		},
+		{
+			name:    "Different sobjects",
+			upsert:  &upsert{},
+			records: []sobject.Upserter{account("External_Id__c"), contact},
+			wantErr: true,
+		},
+		{
+			name:    "Different external fields",
+			upsert:  &upsert{},
+			records: []sobject.Upserter{account("External_Id__c"), account("Other_Id__c")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resource{
+				upsert: tt.upsert,
+			}
+			_, err := r.Upsert(true, tt.records)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.Upsert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
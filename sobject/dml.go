@@ -18,6 +18,15 @@ type InsertValue struct {
 	Errors  []sfdc.Error `json:"errors"`
 }
 
+// Err returns the errors of the value when Salesforce reported that the
+// record failed, even though the response was successful, or nil.
+func (value InsertValue) Err() error {
+	if value.Success || len(value.Errors) == 0 {
+		return nil
+	}
+	return sfdc.Errors(value.Errors)
+}
+
 // UpsertValue is the value that is return when a
 // record as been upserted into Salesforce.
 //
@@ -78,6 +87,19 @@ type Deleter interface {
 	ID() string
 }
 
+// AutoAssigner can be implemented by an Inserter, Updater or Upserter to
+// control the assignment rules triggered by the write, like routing leads
+// and cases.
+//
+// AutoAssign is the Sforce-Auto-Assign header value.  TRUE will run the active
+// assignment rule, FALSE will not run any assignment rule and an assignment
+// rule ID will run that rule.
+type AutoAssigner interface {
+	AutoAssign() string
+}
+
+const autoAssignHeader = "Sforce-Auto-Assign"
+
 type dml struct {
 	session session.ServiceFormatter
 }
@@ -114,6 +136,7 @@ func (d *dml) insertRequest(inserter Inserter) (*http.Request, error) {
 
 	request.Header.Add("Accept", "application/json")
 	request.Header.Add("Content-Type", "application/json")
+	d.autoAssign(request, inserter)
 	d.session.AuthorizationHeader(request)
 	return request, nil
 
@@ -149,6 +172,9 @@ func (d *dml) insertResponse(request *http.Request) (InsertValue, error) {
 	if err != nil {
 		return InsertValue{}, err
 	}
+	if err := value.Err(); err != nil {
+		return InsertValue{}, fmt.Errorf("insert response err: %w", err)
+	}
 
 	return value, nil
 }
@@ -180,6 +206,7 @@ func (d *dml) updateRequest(updater Updater) (*http.Request, error) {
 
 	request.Header.Add("Accept", "application/json")
 	request.Header.Add("Content-Type", "application/json")
+	d.autoAssign(request, updater)
 	d.session.AuthorizationHeader(request)
 	return request, nil
 
@@ -245,6 +272,7 @@ func (d *dml) upsertRequest(upserter Upserter) (*http.Request, error) {
 
 	request.Header.Add("Accept", "application/json")
 	request.Header.Add("Content-Type", "application/json")
+	d.autoAssign(request, upserter)
 	d.session.AuthorizationHeader(request)
 	return request, nil
 }
@@ -265,6 +293,9 @@ func (d *dml) upsertResponse(request *http.Request) (UpsertValue, error) {
 		if err != nil {
 			return UpsertValue{}, err
 		}
+		if err := value.Err(); err != nil {
+			return UpsertValue{}, fmt.Errorf("upsert response err: %w", err)
+		}
 
 	case http.StatusNoContent:
 		break // out of the switch
@@ -315,3 +346,11 @@ func (d *dml) deleteResponse(request *http.Request) error {
 
 	return nil
 }
+
+func (d *dml) autoAssign(request *http.Request, record interface{}) {
+	if assigner, ok := record.(AutoAssigner); ok {
+		if value := assigner.AutoAssign(); value != "" {
+			request.Header.Add(autoAssignHeader, value)
+		}
+	}
+}
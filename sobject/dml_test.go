@@ -150,6 +150,42 @@ func Test_dml_Insert(t *testing.T) {
 			want:    InsertValue{},
 			wantErr: true,
 		},
+		{
+			name: "Response Created With Errors",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						resp := `
+						{
+							"id" : "",
+							"errors" : [{
+								"statusCode" : "DUPLICATES_DETECTED",
+								"message" : "Use one of these records?",
+								"fields" : [ ]
+							}],
+							"success" : false
+						}`
+
+						return &http.Response{
+							StatusCode: http.StatusCreated,
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			args: args{
+				inserter: &mockInserter{
+					sobject: "Account",
+					fields: map[string]interface{}{
+						"Name": "Test Account",
+					},
+				},
+			},
+			want:    InsertValue{},
+			wantErr: true,
+		},
 		{
 			name: "Response Passing",
 			fields: fields{
@@ -716,3 +752,63 @@ func Test_dml_Delete(t *testing.T) {
 		})
 	}
 }
+
+type mockAutoAssignInserter struct {
+	mockInserter
+	autoAssign string
+}
+
+func (mock *mockAutoAssignInserter) AutoAssign() string {
+	return mock.autoAssign
+}
+
+func Test_dml_autoAssign(t *testing.T) {
+	tests := []struct {
+		name     string
+		inserter Inserter
+		want     string
+	}{
+		{
+			name: "assignment rule",
+			inserter: &mockAutoAssignInserter{
+				mockInserter: mockInserter{
+					sobject: "Lead",
+				},
+				autoAssign: "01QD0000000EqAA",
+			},
+			want: "01QD0000000EqAA",
+		},
+		{
+			name: "empty value",
+			inserter: &mockAutoAssignInserter{
+				mockInserter: mockInserter{
+					sobject: "Lead",
+				},
+			},
+			want: "",
+		},
+		{
+			name: "not an auto assigner",
+			inserter: &mockInserter{
+				sobject: "Lead",
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &dml{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+				},
+			}
+			request, err := d.insertRequest(tt.inserter)
+			if err != nil {
+				t.Fatalf("dml.insertRequest() error = %v", err)
+			}
+			if got := request.Header.Get(autoAssignHeader); got != tt.want {
+				t.Errorf("dml.insertRequest() %s = %v, want %v", autoAssignHeader, got, tt.want)
+			}
+		})
+	}
+}
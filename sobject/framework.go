@@ -2,6 +2,7 @@ package sobject
 
 import (
 	"fmt"
+	"io"
 	"regexp"
 	"time"
 
@@ -33,6 +34,7 @@ type Resources struct {
 	list     *list
 	dml      *dml
 	query    *query
+	blob     *blob
 }
 
 const objectEndpoint = "/sobjects/"
@@ -66,6 +68,9 @@ func NewResources(session session.ServiceFormatter) (*Resources, error) {
 		query: &query{
 			session: session,
 		},
+		blob: &blob{
+			session: session,
+		},
 	}, nil
 }
 
@@ -182,6 +187,25 @@ func (r *Resources) Query(querier Querier) (*sfdc.Record, error) {
 	return r.query.callout(querier)
 }
 
+// QueryModifiedSince returns a SObject record using the Salesforce ID, when it was
+// modified since the time.  If the record was not modified, ErrNotModified is
+// returned without the record, which saves reading it when polling for changes.
+func (r *Resources) QueryModifiedSince(querier Querier, since time.Time) (*sfdc.Record, error) {
+	if r.query == nil {
+		return nil, errors.New("salesforce api is not initialized properly")
+	}
+
+	if querier == nil {
+		return nil, errors.New("querier can not be nil")
+	}
+
+	if since.IsZero() {
+		return nil, errors.New("sobject salesforce api: since can not be zero")
+	}
+
+	return r.query.modifiedSinceCallout(querier, since)
+}
+
 // ExternalQuery returns a SObject record using an external ID field.
 func (r *Resources) ExternalQuery(querier ExternalQuerier) (*sfdc.Record, error) {
 	if r.query == nil {
@@ -231,6 +255,41 @@ func (r *Resources) UpdatedRecords(sobject string, startDate, endDate time.Time)
 	return r.query.updatedRecordsCallout(sobject, startDate, endDate)
 }
 
+// GetUpdated returns the IDs of the records of the sobject that were updated from
+// the start to the end, for incremental replication.  Salesforce only keeps the
+// changes of the last 30 days.
+func (r *Resources) GetUpdated(sobject string, start, end time.Time) ([]string, error) {
+	if end.Before(start) {
+		return nil, fmt.Errorf("sobject salesforce api: end %v is before start %v", end, start)
+	}
+
+	records, err := r.UpdatedRecords(sobject, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return records.Records, nil
+}
+
+// GetDeleted returns the IDs of the records of the sobject that were deleted from
+// the start to the end, for incremental replication.  Salesforce only keeps the
+// deleted records of the last 15 days, or until they are removed from the recycle
+// bin.
+func (r *Resources) GetDeleted(sobject string, start, end time.Time) ([]string, error) {
+	if end.Before(start) {
+		return nil, fmt.Errorf("sobject salesforce api: end %v is before start %v", end, start)
+	}
+
+	records, err := r.DeletedRecords(sobject, start, end)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(records.Records))
+	for idx, record := range records.Records {
+		ids[idx] = record.ID
+	}
+	return ids, nil
+}
+
 // GetContent returns the blob from a content SObject.
 func (r *Resources) GetContent(id string, content ContentType) ([]byte, error) {
 	if r.query == nil {
@@ -250,3 +309,64 @@ func (r *Resources) GetContent(id string, content ContentType) ([]byte, error) {
 
 	return r.query.contentCallout(id, content)
 }
+
+// DownloadBlob streams the binary field of a record to the writer, like the
+// VersionData of a ContentVersion or the Body of an Attachment, without reading
+// it into memory.
+func (r *Resources) DownloadBlob(sobject, id, field string, w io.Writer) error {
+	if r.blob == nil {
+		return errors.New("salesforce api is not initialized properly")
+	}
+
+	matching, err := regexp.MatchString(`\w`, sobject)
+	if err != nil {
+		return err
+	}
+
+	if matching == false {
+		return fmt.Errorf("sobject salesforce api: %s is not a valid sobject", sobject)
+	}
+
+	if id == "" {
+		return errors.New("sobject salesforce api: id can not be empty")
+	}
+
+	if field == "" {
+		return errors.New("sobject salesforce api: field can not be empty")
+	}
+
+	if w == nil {
+		return errors.New("sobject salesforce api: writer can not be nil")
+	}
+
+	return r.blob.downloadCallout(sobject, id, field, w)
+}
+
+// InsertBlob will create a new Salesforce record with a binary field, like a
+// ContentVersion with its VersionData or an Attachment with its Body.  The
+// record's fields and the data are sent as a multipart request, and the ID of
+// the new record is returned.
+func (r *Resources) InsertBlob(sobject string, fields map[string]interface{}, blobField string, data io.Reader) (string, error) {
+	if r.blob == nil {
+		return "", errors.New("salesforce api is not initialized properly")
+	}
+
+	matching, err := regexp.MatchString(`\w`, sobject)
+	if err != nil {
+		return "", err
+	}
+
+	if matching == false {
+		return "", fmt.Errorf("sobject salesforce api: %s is not a valid sobject", sobject)
+	}
+
+	if blobField == "" {
+		return "", errors.New("sobject salesforce api: blob field can not be empty")
+	}
+
+	if data == nil {
+		return "", errors.New("sobject salesforce api: data can not be nil")
+	}
+
+	return r.blob.insertCallout(sobject, fields, blobField, data)
+}
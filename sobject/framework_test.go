@@ -1,7 +1,10 @@
 package sobject
 
 import (
+	"io/ioutil"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -548,6 +551,124 @@ func TestSalesforceAPI_UpdatedRecords(t *testing.T) {
 	}
 }
 
+func TestResources_GetUpdated(t *testing.T) {
+	start := time.Date(2013, time.May, 3, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		query   *query
+		end     time.Time
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Passing",
+			query: &query{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						resp := `{"ids":["a00D0000008pQR5IAM","a00D0000008pQRGIA2"],"latestDateCovered":"2013-05-08T21:20:00.000+0000"}`
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			end:     start.AddDate(0, 0, 7),
+			want:    []string{"a00D0000008pQR5IAM", "a00D0000008pQRGIA2"},
+			wantErr: false,
+		},
+		{
+			name: "End Before Start",
+			query: &query{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+				},
+			},
+			end:     start.AddDate(0, 0, -1),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Resources{
+				query: tt.query,
+			}
+			got, err := a.GetUpdated("Account", start, tt.end)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resources.GetUpdated() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resources.GetUpdated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResources_GetDeleted(t *testing.T) {
+	start := time.Date(2013, time.May, 3, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		query   *query
+		end     time.Time
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Passing",
+			query: &query{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						resp := `{"deletedRecords":[{"id":"a00D0000008pQRAIA2","deletedDate":"2013-05-03T15:57:00.000+0000"}],` +
+							`"earliestDateAvailable":"2013-05-03T15:57:00.000+0000","latestDateCovered":"2013-05-08T21:20:00.000+0000"}`
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			end:     start.AddDate(0, 0, 7),
+			want:    []string{"a00D0000008pQRAIA2"},
+			wantErr: false,
+		},
+		{
+			name: "End Before Start",
+			query: &query{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+				},
+			},
+			end:     start.AddDate(0, 0, -1),
+			wantErr: true,
+		},
+		{
+			name:    "No Query field",
+			end:     start.AddDate(0, 0, 7),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Resources{
+				query: tt.query,
+			}
+			got, err := a.GetDeleted("Account", start, tt.end)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resources.GetDeleted() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resources.GetDeleted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSalesforceAPI_GetContent(t *testing.T) {
 	type fields struct {
 		metadata *metadata
@@ -658,6 +779,11 @@ func TestNewResources(t *testing.T) {
 						url: "https://test.salesforce.com",
 					},
 				},
+				blob: &blob{
+					session: &mockSessionFormatter{
+						url: "https://test.salesforce.com",
+					},
+				},
 			},
 			wantErr: false,
 		},
@@ -2,6 +2,7 @@ package sobject
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -66,6 +67,10 @@ type UpdatedRecords struct {
 	LatestDate    time.Time `json:"-"`
 }
 
+// ErrNotModified is returned by QueryModifiedSince when the record was not
+// modified since the time.
+var ErrNotModified = errors.New("sobject query: record was not modified")
+
 // ContentType is indicator of the content type in Salesforce blob.
 type ContentType string
 
@@ -122,6 +127,18 @@ func (q *query) queryRequest(querier Querier) (*http.Request, error) {
 
 }
 
+// modifiedSinceCallout is callout with the If-Modified-Since header, so Salesforce
+// returns 304 Not Modified when the record was not changed since then.
+func (q *query) modifiedSinceCallout(querier Querier, since time.Time) (*sfdc.Record, error) {
+	request, err := q.queryRequest(querier)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("If-Modified-Since", since.UTC().Format(http.TimeFormat))
+
+	return q.queryResponse(request)
+}
+
 func (q *query) queryResponse(request *http.Request) (*sfdc.Record, error) {
 	response, err := q.session.Client().Do(request)
 
@@ -132,6 +149,10 @@ func (q *query) queryResponse(request *http.Request) (*sfdc.Record, error) {
 	decoder := json.NewDecoder(response.Body)
 	defer response.Body.Close()
 
+	if response.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	}
+
 	if response.StatusCode != http.StatusOK {
 		var queryErrs []sfdc.Error
 		err = decoder.Decode(&queryErrs)
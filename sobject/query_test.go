@@ -233,6 +233,59 @@ func Test_query_Query(t *testing.T) {
 	}
 }
 
+func Test_query_QueryModifiedSince(t *testing.T) {
+	since := time.Date(2020, time.January, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantErr  error
+		wantName interface{}
+	}{
+		{
+			name:     "Modified",
+			status:   http.StatusOK,
+			body:     `{"attributes":{"type":"Account","url":"/services/data/v44.0/sobjects/Account/SomeID"},"Name":"Golang"}`,
+			wantName: "Golang",
+		},
+		{
+			name:    "Not Modified",
+			status:  http.StatusNotModified,
+			wantErr: ErrNotModified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &query{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.Header.Get("If-Modified-Since") != "Thu, 02 Jan 2020 15:04:05 GMT" {
+							t.Errorf("If-Modified-Since = %s", req.Header.Get("If-Modified-Since"))
+						}
+						return &http.Response{
+							StatusCode: tt.status,
+							Body:       ioutil.NopCloser(strings.NewReader(tt.body)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			}
+			got, err := q.modifiedSinceCallout(&mockQuery{sobject: "Account", id: "SomeID"}, since)
+			if err != tt.wantErr {
+				t.Errorf("query.modifiedSinceCallout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if name, _ := got.FieldValue("Name"); name != tt.wantName {
+				t.Errorf("query.modifiedSinceCallout() Name = %v, want %v", name, tt.wantName)
+			}
+		})
+	}
+}
+
 type mockExternalQuery struct {
 	sobject  string
 	id       string
@@ -39,6 +39,10 @@ type Resource struct {
 
 const objectEndpoint = "/composite/tree/"
 
+// MaxRecords is the maximum number of records, parents and children
+// combined, that can be inserted with one composite tree request.
+const MaxRecords = 200
+
 // NewResource creates a new composite tree resource from the session.
 func NewResource(session session.ServiceFormatter) (*Resource, error) {
 	if session == nil {
@@ -68,9 +72,47 @@ func (r *Resource) Insert(inserter Inserter) (*Value, error) {
 	if matching == false {
 		return nil, fmt.Errorf("tree resourse: %s is not a valid sobject", sobject)
 	}
+	if count := countRecords(inserter.Records()); count > MaxRecords {
+		return nil, fmt.Errorf("tree resourse: %d records exceeds the maximum of %d", count, MaxRecords)
+	}
 
 	return r.callout(inserter)
 }
+
+// InsertTree will call the composite tree API to insert the records, and their
+// children, of the sobject.
+func (r *Resource) InsertTree(sobject string, records []*Record) (*Value, error) {
+	return r.Insert(&recordInserter{
+		sobject: sobject,
+		records: records,
+	})
+}
+
+type recordInserter struct {
+	sobject string
+	records []*Record
+}
+
+func (i *recordInserter) SObject() string {
+	return i.sobject
+}
+func (i *recordInserter) Records() []*Record {
+	return i.records
+}
+
+func countRecords(records []*Record) int {
+	count := 0
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		count++
+		for _, children := range record.Records {
+			count += countRecords(children)
+		}
+	}
+	return count
+}
 func (r *Resource) callout(inserter Inserter) (*Value, error) {
 
 	request, err := r.request(inserter)
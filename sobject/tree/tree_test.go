@@ -1,6 +1,7 @@
 package tree
 
 import (
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"reflect"
@@ -296,6 +297,149 @@ func TestResource_Insert(t *testing.T) {
 	}
 }
 
+func TestResource_InsertTree(t *testing.T) {
+	tooMany := make([]*Record, MaxRecords)
+	for idx := range tooMany {
+		tooMany[idx] = &Record{
+			Attributes: Attributes{
+				Type:        "Account",
+				ReferenceID: fmt.Sprintf("ref%d", idx),
+			},
+		}
+	}
+	tooMany[0].Records = map[string][]*Record{
+		"Contacts": {
+			{
+				Attributes: Attributes{
+					Type:        "Contact",
+					ReferenceID: "child",
+				},
+			},
+		},
+	}
+
+	type fields struct {
+		session session.ServiceFormatter
+	}
+	type args struct {
+		sobject string
+		records []*Record
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *Value
+		wantErr bool
+	}{
+		{
+			name: "success",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "something.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.String() != "something.com/composite/tree/Account" {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Bad URL: " + req.URL.String(),
+								Body:       ioutil.NopCloser(strings.NewReader("resp")),
+								Header:     make(http.Header),
+							}
+						}
+						resp := `
+						{
+							"hasErrors" : false,
+							"results" : [{
+								"referenceId" : "ref1",
+								"id" : "001D000000K0fXOIAZ"
+							},{
+								"referenceId" : "ref2",
+								"id" : "003D000000QV9n2IAD"
+							}]
+						}`
+						return &http.Response{
+							StatusCode: http.StatusCreated,
+							Status:     "Created",
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			args: args{
+				sobject: "Account",
+				records: []*Record{
+					{
+						Attributes: Attributes{
+							Type:        "Account",
+							ReferenceID: "ref1",
+						},
+						Fields: map[string]interface{}{
+							"name": "SampleAccount",
+						},
+						Records: map[string][]*Record{
+							"Contacts": {
+								{
+									Attributes: Attributes{
+										Type:        "Contact",
+										ReferenceID: "ref2",
+									},
+									Fields: map[string]interface{}{
+										"lastname": "Smith",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &Value{
+				HasErrors: false,
+				Results: []InsertValue{
+					{
+						ReferenceID: "ref1",
+						ID:          "001D000000K0fXOIAZ",
+					},
+					{
+						ReferenceID: "ref2",
+						ID:          "003D000000QV9n2IAD",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "too many records",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "something.com",
+				},
+			},
+			args: args{
+				sobject: "Account",
+				records: tooMany,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resource{
+				session: tt.fields.session,
+			}
+			got, err := r.InsertTree(tt.args.sobject, tt.args.records)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.InsertTree() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resource.InsertTree() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewResource(t *testing.T) {
 	type args struct {
 		session session.ServiceFormatter
@@ -0,0 +1,109 @@
+package soql
+
+import "fmt"
+
+// DateLiteral is a SOQL date literal, like TODAY or LAST_N_DAYS:30, which is
+// a relative range of dates.  A date literal is not quoted in the where
+// expressions, since a quoted date literal does not match any records.
+type DateLiteral string
+
+// These are the SOQL date literals.
+const (
+	Yesterday         DateLiteral = "YESTERDAY"
+	Today             DateLiteral = "TODAY"
+	Tomorrow          DateLiteral = "TOMORROW"
+	LastWeek          DateLiteral = "LAST_WEEK"
+	ThisWeek          DateLiteral = "THIS_WEEK"
+	NextWeek          DateLiteral = "NEXT_WEEK"
+	LastMonth         DateLiteral = "LAST_MONTH"
+	ThisMonth         DateLiteral = "THIS_MONTH"
+	NextMonth         DateLiteral = "NEXT_MONTH"
+	Last90Days        DateLiteral = "LAST_90_DAYS"
+	Next90Days        DateLiteral = "NEXT_90_DAYS"
+	LastQuarter       DateLiteral = "LAST_QUARTER"
+	ThisQuarter       DateLiteral = "THIS_QUARTER"
+	NextQuarter       DateLiteral = "NEXT_QUARTER"
+	LastYear          DateLiteral = "LAST_YEAR"
+	ThisYear          DateLiteral = "THIS_YEAR"
+	NextYear          DateLiteral = "NEXT_YEAR"
+	LastFiscalQuarter DateLiteral = "LAST_FISCAL_QUARTER"
+	ThisFiscalQuarter DateLiteral = "THIS_FISCAL_QUARTER"
+	NextFiscalQuarter DateLiteral = "NEXT_FISCAL_QUARTER"
+	LastFiscalYear    DateLiteral = "LAST_FISCAL_YEAR"
+	ThisFiscalYear    DateLiteral = "THIS_FISCAL_YEAR"
+	NextFiscalYear    DateLiteral = "NEXT_FISCAL_YEAR"
+)
+
+// LastNDays is the date literal for the last n days, including today.
+func LastNDays(n int) DateLiteral {
+	return relativeDate("LAST_N_DAYS", n)
+}
+
+// NextNDays is the date literal for the next n days, including today.
+func NextNDays(n int) DateLiteral {
+	return relativeDate("NEXT_N_DAYS", n)
+}
+
+// NDaysAgo is the date literal for the day n days ago.
+func NDaysAgo(n int) DateLiteral {
+	return relativeDate("N_DAYS_AGO", n)
+}
+
+// LastNWeeks is the date literal for the last n weeks, not including this week.
+func LastNWeeks(n int) DateLiteral {
+	return relativeDate("LAST_N_WEEKS", n)
+}
+
+// NextNWeeks is the date literal for the next n weeks, not including this week.
+func NextNWeeks(n int) DateLiteral {
+	return relativeDate("NEXT_N_WEEKS", n)
+}
+
+// NWeeksAgo is the date literal for the week n weeks ago.
+func NWeeksAgo(n int) DateLiteral {
+	return relativeDate("N_WEEKS_AGO", n)
+}
+
+// LastNMonths is the date literal for the last n months, not including this month.
+func LastNMonths(n int) DateLiteral {
+	return relativeDate("LAST_N_MONTHS", n)
+}
+
+// NextNMonths is the date literal for the next n months, not including this month.
+func NextNMonths(n int) DateLiteral {
+	return relativeDate("NEXT_N_MONTHS", n)
+}
+
+// NMonthsAgo is the date literal for the month n months ago.
+func NMonthsAgo(n int) DateLiteral {
+	return relativeDate("N_MONTHS_AGO", n)
+}
+
+// LastNQuarters is the date literal for the last n quarters, not including this quarter.
+func LastNQuarters(n int) DateLiteral {
+	return relativeDate("LAST_N_QUARTERS", n)
+}
+
+// NextNQuarters is the date literal for the next n quarters, not including this quarter.
+func NextNQuarters(n int) DateLiteral {
+	return relativeDate("NEXT_N_QUARTERS", n)
+}
+
+// LastNYears is the date literal for the last n years, not including this year.
+func LastNYears(n int) DateLiteral {
+	return relativeDate("LAST_N_YEARS", n)
+}
+
+// NextNYears is the date literal for the next n years, not including this year.
+func NextNYears(n int) DateLiteral {
+	return relativeDate("NEXT_N_YEARS", n)
+}
+
+// NYearsAgo is the date literal for the year n years ago.
+func NYearsAgo(n int) DateLiteral {
+	return relativeDate("N_YEARS_AGO", n)
+}
+
+func relativeDate(literal string, n int) DateLiteral {
+	return DateLiteral(fmt.Sprintf("%s:%d", literal, n))
+}
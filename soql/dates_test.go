@@ -0,0 +1,59 @@
+package soql
+
+import "testing"
+
+func TestDateLiteral_Where(t *testing.T) {
+	tests := []struct {
+		name  string
+		where func() (*WhereClause, error)
+		want  string
+	}{
+		{
+			name: "equals",
+			where: func() (*WhereClause, error) {
+				return WhereEquals("CloseDate", ThisMonth)
+			},
+			want: "CloseDate = THIS_MONTH",
+		},
+		{
+			name: "not equals",
+			where: func() (*WhereClause, error) {
+				return WhereNotEquals("CloseDate", Yesterday)
+			},
+			want: "CloseDate != YESTERDAY",
+		},
+		{
+			name: "greater than",
+			where: func() (*WhereClause, error) {
+				return WhereGreaterThan("CreatedDate", LastNDays(30), true)
+			},
+			want: "CreatedDate >= LAST_N_DAYS:30",
+		},
+		{
+			name: "less than",
+			where: func() (*WhereClause, error) {
+				return WhereLessThan("CreatedDate", NDaysAgo(7), false)
+			},
+			want: "CreatedDate < N_DAYS_AGO:7",
+		},
+		{
+			name: "in",
+			where: func() (*WhereClause, error) {
+				return WhereIn("CloseDate", []interface{}{ThisQuarter, NextNQuarters(2)})
+			},
+			want: "CloseDate IN (THIS_QUARTER,NEXT_N_QUARTERS:2)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, err := tt.where()
+			if err != nil {
+				t.Errorf("where error = %v", err)
+				return
+			}
+			if got := where.Expression(); got != tt.want {
+				t.Errorf("where = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package soql
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/pkg/errors"
+)
+
+// QueryPlan is the response of the query explain.  The plans are
+// sorted from the lowest relative cost, which is the plan Salesforce
+// will use for the query.
+type QueryPlan struct {
+	Plans       []Plan `json:"plans"`
+	SourceQuery string `json:"sourceQuery"`
+}
+
+// Plan is one of the plans that Salesforce considered for the query.
+type Plan struct {
+	Cardinality          int        `json:"cardinality"`
+	Fields               []string   `json:"fields"`
+	LeadingOperationType string     `json:"leadingOperationType"`
+	Notes                []PlanNote `json:"notes"`
+	RelativeCost         float64    `json:"relativeCost"`
+	SObjectCardinality   int        `json:"sobjectCardinality"`
+	SObjectType          string     `json:"sobjectType"`
+}
+
+// PlanNote is a note on why an index was not used by the plan.
+type PlanNote struct {
+	Description   string   `json:"description"`
+	Fields        []string `json:"fields"`
+	TableEnumOrID string   `json:"tableEnumOrId"`
+}
+
+// Explain will call out to the Salesforce org for the query plan of the SOQL
+// without running the query.  This can be used to determine if the query
+// will use an index.
+func (r *Resource) Explain(querier QueryFormatter) (*QueryPlan, error) {
+	if querier == nil {
+		return nil, errors.New("soql resource explain: querier can not be nil")
+	}
+
+	query, err := querier.Format()
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Add("explain", query)
+	queryURL := r.session.ServiceURL() + "/query/?" + form.Encode()
+	if len(queryURL) > sfdc.MaxURLLength {
+		return nil, errors.Wrapf(ErrQueryTooLong, "%d characters exceeds %d", len(queryURL), sfdc.MaxURLLength)
+	}
+
+	request, err := http.NewRequest(http.MethodGet, queryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Add("Accept", "application/json")
+	r.session.AuthorizationHeader(request)
+
+	response, err := r.session.Client().Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, sfdc.HandleError(response)
+	}
+
+	var plan QueryPlan
+	err = json.NewDecoder(response.Body).Decode(&plan)
+	if err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
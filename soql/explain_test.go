@@ -0,0 +1,159 @@
+package soql
+
+import (
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResource_Explain(t *testing.T) {
+	type fields struct {
+		session *mockSessionFormatter
+	}
+	type args struct {
+		querier QueryFormatter
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *QueryPlan
+		wantErr bool
+	}{
+		{
+			name: "Passing",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.Query().Get("explain") != "SELECT Id FROM Account WHERE Name = 'Golang'" {
+							return &http.Response{
+								StatusCode: http.StatusBadRequest,
+								Body:       ioutil.NopCloser(strings.NewReader(req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+						resp := `
+						{
+							"plans" : [ {
+								"cardinality" : 1,
+								"fields" : [ "Name" ],
+								"leadingOperationType" : "Index",
+								"notes" : [ ],
+								"relativeCost" : 0.2,
+								"sobjectCardinality" : 12,
+								"sobjectType" : "Account"
+							}, {
+								"cardinality" : 1,
+								"fields" : [ ],
+								"leadingOperationType" : "TableScan",
+								"notes" : [ {
+									"description" : "Not considering filter for optimization because unindexed",
+									"fields" : [ "IsDeleted" ],
+									"tableEnumOrId" : "Account"
+								} ],
+								"relativeCost" : 0.65,
+								"sobjectCardinality" : 12,
+								"sobjectType" : "Account"
+							} ],
+							"sourceQuery" : "SELECT Id FROM Account WHERE Name = 'Golang'"
+						}`
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			args: args{
+				querier: &mockQuerier{
+					stmt: "SELECT Id FROM Account WHERE Name = 'Golang'",
+				},
+			},
+			want: &QueryPlan{
+				Plans: []Plan{
+					{
+						Cardinality:          1,
+						Fields:               []string{"Name"},
+						LeadingOperationType: "Index",
+						Notes:                []PlanNote{},
+						RelativeCost:         0.2,
+						SObjectCardinality:   12,
+						SObjectType:          "Account",
+					},
+					{
+						Cardinality:          1,
+						Fields:               []string{},
+						LeadingOperationType: "TableScan",
+						Notes: []PlanNote{
+							{
+								Description:   "Not considering filter for optimization because unindexed",
+								Fields:        []string{"IsDeleted"},
+								TableEnumOrID: "Account",
+							},
+						},
+						RelativeCost:       0.65,
+						SObjectCardinality: 12,
+						SObjectType:        "Account",
+					},
+				},
+				SourceQuery: "SELECT Id FROM Account WHERE Name = 'Golang'",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						resp := `
+						[ {
+							"message" : "unexpected token: FROM",
+							"errorCode" : "MALFORMED_QUERY"
+						} ]`
+						return &http.Response{
+							StatusCode: http.StatusBadRequest,
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			args: args{
+				querier: &mockQuerier{
+					stmt: "SELECT FROM Account",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "No Querier",
+			fields: fields{
+				session: &mockSessionFormatter{},
+			},
+			args:    args{},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resource{
+				session: tt.fields.session,
+			}
+			got, err := r.Explain(tt.args.querier)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.Explain() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resource.Explain() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package soql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The FIELDS macros select a group of fields without listing them.
+const (
+	// FieldsAll selects all of the object's fields.
+	FieldsAll = "FIELDS(ALL)"
+	// FieldsStandard selects the object's standard fields.
+	FieldsStandard = "FIELDS(STANDARD)"
+	// FieldsCustom selects the object's custom fields.
+	FieldsCustom = "FIELDS(CUSTOM)"
+)
+
+// MaxFieldsLimit is the largest LIMIT of a query that selects the FIELDS(ALL)
+// or FIELDS(CUSTOM) macro, which Salesforce requires to be bounded.
+const MaxFieldsLimit = 200
+
+// fieldsLimit returns the limit of the query.  If the field list has a FIELDS
+// macro that must be bounded, the limit defaults to MaxFieldsLimit and can
+// not be more than it.
+func fieldsLimit(fieldList []string, limit int) (int, error) {
+	if hasUnboundedFieldsMacro(fieldList) == false {
+		return limit, nil
+	}
+	switch {
+	case limit > MaxFieldsLimit:
+		return 0, fmt.Errorf("builder: the LIMIT of a FIELDS(ALL) or FIELDS(CUSTOM) query can not be more than %d", MaxFieldsLimit)
+	case limit <= 0:
+		return MaxFieldsLimit, nil
+	default:
+		return limit, nil
+	}
+}
+
+func hasUnboundedFieldsMacro(fieldList []string) bool {
+	for _, field := range fieldList {
+		switch strings.ToUpper(strings.Join(strings.Fields(field), "")) {
+		case FieldsAll, FieldsCustom:
+			return true
+		}
+	}
+	return false
+}
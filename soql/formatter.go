@@ -19,7 +19,8 @@ import (
 //
 // Order is the SOQL ordering
 //
-// Limit is the SOQL record limit
+// Limit is the SOQL record limit.  When the FieldList has the FieldsAll or
+// FieldsCustom macro, the limit defaults to, and can not be more than, MaxFieldsLimit
 //
 // Offset is the SOQL record offset
 type QueryInput struct {
@@ -59,6 +60,9 @@ func NewQuery(input QueryInput) (*Query, error) {
 	if len(input.FieldList) == 0 {
 		return nil, errors.New("builder: field list can not be empty")
 	}
+	if _, err := fieldsLimit(input.FieldList, input.Limit); err != nil {
+		return nil, err
+	}
 
 	return &Query{
 		objectType: input.ObjectType,
@@ -105,8 +109,12 @@ func (b *Query) Format() (string, error) {
 			return "", err
 		}
 	}
-	if b.limit > 0 {
-		soql += fmt.Sprintf(" LIMIT %d", b.limit)
+	limit, err := fieldsLimit(b.fieldList, b.limit)
+	if err != nil {
+		return "", err
+	}
+	if limit > 0 {
+		soql += fmt.Sprintf(" LIMIT %d", limit)
 	}
 	if b.offset > 0 {
 		soql += fmt.Sprintf(" OFFSET %d", b.offset)
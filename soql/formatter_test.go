@@ -1088,6 +1088,53 @@ func TestBuilder_Query(t *testing.T) {
 			want:    "SELECT Name,CreatedBy FROM Account OFFSET 150",
 			wantErr: false,
 		},
+		{
+			name: "Fields All",
+			fields: fields{
+				objectType: "Account",
+				fieldList: []string{
+					FieldsAll,
+				},
+			},
+			want:    "SELECT FIELDS(ALL) FROM Account LIMIT 200",
+			wantErr: false,
+		},
+		{
+			name: "Fields Custom Limit",
+			fields: fields{
+				objectType: "Account",
+				fieldList: []string{
+					"Name",
+					"fields(custom)",
+				},
+				limit: 50,
+			},
+			want:    "SELECT Name,fields(custom) FROM Account LIMIT 50",
+			wantErr: false,
+		},
+		{
+			name: "Fields Standard",
+			fields: fields{
+				objectType: "Account",
+				fieldList: []string{
+					FieldsStandard,
+				},
+			},
+			want:    "SELECT FIELDS(STANDARD) FROM Account",
+			wantErr: false,
+		},
+		{
+			name: "Fields All Limit Too Large",
+			fields: fields{
+				objectType: "Account",
+				fieldList: []string{
+					FieldsAll,
+				},
+				limit: MaxFieldsLimit + 1,
+			},
+			want:    "",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1165,6 +1212,20 @@ func TestNewBuilder(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "fields limit too large",
+			args: args{
+				input: QueryInput{
+					ObjectType: "Account",
+					FieldList: []string{
+						FieldsAll,
+					},
+					Limit: 500,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,64 @@
+package soql
+
+import (
+	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/enrique-esquivel/go-sfdc/internal/pagination"
+	"github.com/pkg/errors"
+)
+
+// MaxIDsPerQuery is the most IDs that QueryByIDs puts in the IN clause of one
+// query, which keeps the request URL under sfdc.MaxURLLength.
+const MaxIDsPerQuery = 300
+
+// QueryByIDs will query the fields of the object's records with the IDs.  The
+// IDs are split into queries of at most MaxIDsPerQuery IDs, and the records of
+// all of the queries, including their next records, are returned.
+func (r *Resource) QueryByIDs(object string, fields []string, ids []string) ([]*sfdc.Record, error) {
+	if object == "" {
+		return nil, errors.New("soql resource query: object can not be empty")
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("soql resource query: fields can not be empty")
+	}
+
+	var records []*sfdc.Record
+	for start := 0; start < len(ids); start += MaxIDsPerQuery {
+		end := start + MaxIDsPerQuery
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		query, err := NewQuery(QueryInput{
+			ObjectType: object,
+			FieldList:  fields,
+			Where: &WhereClause{
+				expression: InClause("Id", ids[start:end]),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := r.Query(query, false)
+		if err != nil {
+			return nil, err
+		}
+		guard := pagination.Guard{MaxPages: r.MaxPages}
+		for {
+			for _, record := range result.Records() {
+				records = append(records, record.Record())
+			}
+			if result.MoreRecords() == false {
+				break
+			}
+			if err := guard.Follow(result.response.NextRecordsURL); err != nil {
+				return nil, errors.Wrap(err, "soql query result")
+			}
+			result, err = result.Next()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,105 @@
+package soql
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/enrique-esquivel/go-sfdc"
+)
+
+func TestResource_QueryByIDs(t *testing.T) {
+	ids := make([]string, MaxIDsPerQuery+1)
+	for idx := range ids {
+		ids[idx] = fmt.Sprintf("001D000000K%07d", idx)
+	}
+	tests := []struct {
+		name    string
+		object  string
+		fields  []string
+		ids     []string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "chunked",
+			object:  "Account",
+			fields:  []string{"Id", "Name"},
+			ids:     ids,
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name:    "no ids",
+			object:  "Account",
+			fields:  []string{"Id"},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name:    "no object",
+			fields:  []string{"Id"},
+			ids:     ids,
+			wantErr: true,
+		},
+		{
+			name:    "no fields",
+			object:  "Account",
+			ids:     ids,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resource{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						record := `{"attributes":{"type":"Account","url":"/services/data/v20.0/sobjects/Account/001D000000K0000000"},"Id":"001D000000K0000000"}`
+						resp := `{"done":true,"totalSize":1,"records":[` + record + `]}`
+						if strings.HasPrefix(req.URL.Query().Get("q"), "SELECT Id,Name FROM Account WHERE Id IN ('001D000000K0000000',") {
+							resp = `{"done":false,"totalSize":2,"nextRecordsUrl":"/services/data/v20.0/query/01gD0000002HU6KIAW-2000","records":[` + record + `]}`
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       ioutil.NopCloser(strings.NewReader(resp)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			}
+			got, err := r.QueryByIDs(tt.object, tt.fields, tt.ids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.QueryByIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("Resource.QueryByIDs() = %d records, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestResource_QueryByIDs_PaginationLoop(t *testing.T) {
+	r := &Resource{
+		session: &mockSessionFormatter{
+			url: "https://test.salesforce.com",
+			client: mockHTTPClient(func(req *http.Request) *http.Response {
+				record := `{"attributes":{"type":"Account","url":"/services/data/v20.0/sobjects/Account/001D000000K0000000"},"Id":"001D000000K0000000"}`
+				resp := `{"done":false,"totalSize":2,"nextRecordsUrl":"/services/data/v20.0/query/01gD0000002HU6KIAW-2000","records":[` + record + `]}`
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(resp)),
+					Header:     make(http.Header),
+				}
+			}),
+		},
+	}
+	_, err := r.QueryByIDs("Account", []string{"Id"}, []string{"001D000000K0000000"})
+	if errors.Is(err, sfdc.ErrPaginationLoop) == false {
+		t.Errorf("Resource.QueryByIDs() error = %v, want %v", err, sfdc.ErrPaginationLoop)
+	}
+}
@@ -0,0 +1,31 @@
+package soql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// noMatch is a condition that does not match any records, since the ID of a
+// record is never null.
+const noMatch = "Id = null"
+
+var quoter = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// InClause forms the field in a set condition from the values, which are
+// quoted and escaped, like Id IN ('001D000000K0fXOIAZ','001D000000K0fXPIAZ').
+// Since an empty set is not valid SOQL, an empty slice forms a condition that
+// does not match any records.
+func InClause(field string, values []string) string {
+	if len(values) == 0 {
+		return noMatch
+	}
+	set := make([]string, len(values))
+	for idx, value := range values {
+		set[idx] = quote(value)
+	}
+	return fmt.Sprintf("%s IN (%s)", field, strings.Join(set, ","))
+}
+
+func quote(value string) string {
+	return "'" + quoter.Replace(value) + "'"
+}
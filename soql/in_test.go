@@ -0,0 +1,46 @@
+package soql
+
+import "testing"
+
+func TestInClause(t *testing.T) {
+	type args struct {
+		field  string
+		values []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "values",
+			args: args{
+				field:  "Id",
+				values: []string{"001D000000K0fXOIAZ", "001D000000K0fXPIAZ"},
+			},
+			want: "Id IN ('001D000000K0fXOIAZ','001D000000K0fXPIAZ')",
+		},
+		{
+			name: "escaped",
+			args: args{
+				field:  "Name",
+				values: []string{`O'Brien`, `C:\Temp`},
+			},
+			want: `Name IN ('O\'Brien','C:\\Temp')`,
+		},
+		{
+			name: "empty",
+			args: args{
+				field: "Id",
+			},
+			want: "Id = null",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InClause(tt.args.field, tt.args.values); got != tt.want {
+				t.Errorf("InClause() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package soql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MaxOffset is the largest OFFSET that Salesforce allows in a SOQL query.
+const MaxOffset = 2000
+
+// QueryPage will query a page of records by appending the LIMIT and OFFSET to the
+// SOQL.  A limit or offset of zero is not added.  The SOQL can not already have a
+// LIMIT or OFFSET outside of a sub-query, and the offset can not be more than
+// MaxOffset.  For larger result sets, the next records of the result should be
+// used.
+func (r *Resource) QueryPage(querier QueryFormatter, limit, offset int) (*QueryResult, error) {
+	if querier == nil {
+		return nil, errors.New("soql resource query: querier can not be nil")
+	}
+	if limit < 0 {
+		return nil, errors.New("soql resource query: limit can not be negative")
+	}
+	if offset < 0 || offset > MaxOffset {
+		return nil, fmt.Errorf("soql resource query: offset must be between 0 and %d", MaxOffset)
+	}
+
+	return r.Query(&pageQuerier{
+		querier: querier,
+		limit:   limit,
+		offset:  offset,
+	}, false)
+}
+
+type pageQuerier struct {
+	querier QueryFormatter
+	limit   int
+	offset  int
+}
+
+func (p *pageQuerier) Format() (string, error) {
+	soql, err := p.querier.Format()
+	if err != nil {
+		return "", err
+	}
+	soql = strings.TrimSpace(soql)
+	if hasTopLevelClause(soql, "LIMIT", "OFFSET") {
+		return "", errors.New("soql resource query: query already has a LIMIT or OFFSET")
+	}
+
+	if p.limit > 0 {
+		soql += fmt.Sprintf(" LIMIT %d", p.limit)
+	}
+	if p.offset > 0 {
+		soql += fmt.Sprintf(" OFFSET %d", p.offset)
+	}
+	return soql, nil
+}
+
+// hasTopLevelClause returns true if one of the keywords is in the SOQL outside of
+// a string literal or sub-query.
+func hasTopLevelClause(soql string, keywords ...string) bool {
+	depth := 0
+	var word strings.Builder
+	inWord := func(c byte) bool {
+		return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+	}
+	matches := func() bool {
+		if depth != 0 {
+			return false
+		}
+		for _, keyword := range keywords {
+			if strings.EqualFold(word.String(), keyword) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for idx := 0; idx < len(soql); idx++ {
+		c := soql[idx]
+		if inWord(c) {
+			word.WriteByte(c)
+			continue
+		}
+		if matches() {
+			return true
+		}
+		word.Reset()
+
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '\'':
+			for idx++; idx < len(soql) && soql[idx] != '\''; idx++ {
+				if soql[idx] == '\\' {
+					idx++
+				}
+			}
+		}
+	}
+	return matches()
+}
@@ -0,0 +1,108 @@
+package soql
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestResource_QueryPage(t *testing.T) {
+	type args struct {
+		querier QueryFormatter
+		limit   int
+		offset  int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "limit and offset",
+			args: args{
+				querier: &mockQuerier{
+					stmt: "SELECT Id,(SELECT Id FROM Contacts LIMIT 5) FROM Account WHERE Name = 'limit' ",
+				},
+				limit:  10,
+				offset: 20,
+			},
+			want:    "SELECT Id,(SELECT Id FROM Contacts LIMIT 5) FROM Account WHERE Name = 'limit' LIMIT 10 OFFSET 20",
+			wantErr: false,
+		},
+		{
+			name: "limit only",
+			args: args{
+				querier: &mockQuerier{
+					stmt: "SELECT Id FROM Account",
+				},
+				limit: 10,
+			},
+			want:    "SELECT Id FROM Account LIMIT 10",
+			wantErr: false,
+		},
+		{
+			name: "offset too large",
+			args: args{
+				querier: &mockQuerier{
+					stmt: "SELECT Id FROM Account",
+				},
+				limit:  10,
+				offset: MaxOffset + 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative limit",
+			args: args{
+				querier: &mockQuerier{
+					stmt: "SELECT Id FROM Account",
+				},
+				limit: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "existing limit",
+			args: args{
+				querier: &mockQuerier{
+					stmt: "SELECT Id FROM Account limit 5",
+				},
+				limit: 10,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no querier",
+			args:    args{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			r := &Resource{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						got = req.URL.Query().Get("q")
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       ioutil.NopCloser(strings.NewReader(`{"done":true,"totalSize":0,"records":[]}`)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			}
+			_, err := r.QueryPage(tt.args.querier, tt.args.limit, tt.args.offset)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.QueryPage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Resource.QueryPage() query = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
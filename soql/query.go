@@ -4,16 +4,59 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/enrique-esquivel/go-sfdc"
 	"github.com/enrique-esquivel/go-sfdc/session"
 	"github.com/pkg/errors"
 )
 
+// ErrQueryTooLong is returned when the encoded query makes the request URL longer
+// than sfdc.MaxURLLength.  Long queries, like ones with large IN clauses, should be split
+// or run with a bulk query job.
+var ErrQueryTooLong = errors.New("soql resource query: query is too long for the request URL")
+
+// DefaultQueryTimeoutRetries and DefaultQueryTimeoutWait are used when the resource
+// does not have a QueryTimeoutRetries or a QueryTimeoutWait.
+const (
+	DefaultQueryTimeoutRetries = 2
+	DefaultQueryTimeoutWait    = time.Second
+)
+
+// MinBatchSize and MaxBatchSize are the range of the batch size that Salesforce
+// accepts for the records of a query page.
+const (
+	MinBatchSize = 200
+	MaxBatchSize = 2000
+)
+
 // Resource is the structure for the Salesforce
 // SOQL API resource.
+//
+// BatchSize is the number of records of each page of the query results, which is
+// sent with the Sforce-Query-Options header and must be between MinBatchSize and
+// MaxBatchSize.  A larger batch size makes fewer requests for small records and a
+// smaller one returns less of wide records at a time.  If zero, the batch size is
+// chosen by Salesforce, which is 2000 records.  Salesforce may return fewer records
+// than the batch size to optimize performance.
+//
+// QueryTimeoutRetries is the number of times the next records of a query are
+// retried when Salesforce returns a QUERY_TIMEOUT error for the page.  If zero,
+// DefaultQueryTimeoutRetries is used, and if negative, the page is not retried.
+//
+// QueryTimeoutWait is how long to wait before the first retry, which is doubled
+// for each retry after it.  If zero, DefaultQueryTimeoutWait is used.
+//
+// MaxPages is the most pages of records that are followed by the helpers that
+// query all of the next records, like QueryByIDs.  If zero, sfdc.DefaultMaxPages is
+// used.
 type Resource struct {
-	session session.ServiceFormatter
+	session             session.ServiceFormatter
+	BatchSize           int
+	QueryTimeoutRetries int
+	QueryTimeoutWait    time.Duration
+	MaxPages            int
 }
 
 // NewResource forms the Salesforce SOQL resource. The
@@ -49,7 +92,7 @@ func (r *Resource) Query(querier QueryFormatter, all bool) (*QueryResult, error)
 
 	response, err := r.queryResponse(request)
 	if err != nil {
-		return nil, err
+		return nil, queryTimeoutErr(err)
 	}
 
 	result, err := newQueryResult(response, r)
@@ -60,30 +103,61 @@ func (r *Resource) Query(querier QueryFormatter, all bool) (*QueryResult, error)
 	return result, nil
 }
 
+// next will query the next records of a query.  A page that times out is
+// retried up to the resource's QueryTimeoutRetries times.
 func (r *Resource) next(recordURL string) (*QueryResult, error) {
-	queryURL := r.session.InstanceURL() + recordURL
-	request, err := http.NewRequest(http.MethodGet, queryURL, nil)
-
-	if err != nil {
-		return nil, err
+	retries := r.QueryTimeoutRetries
+	if retries == 0 {
+		retries = DefaultQueryTimeoutRetries
+	}
+	wait := r.QueryTimeoutWait
+	if wait <= 0 {
+		wait = DefaultQueryTimeoutWait
 	}
 
-	request.Header.Add("Accept", "application/json")
-	r.session.AuthorizationHeader(request)
+	var response queryResponse
+	for attempt := 0; ; attempt++ {
+		request, err := r.nextRequest(recordURL)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err = r.queryResponse(request)
+		if err == nil {
+			break
+		}
+		if sfdc.IsQueryTimeout(err) == false || attempt >= retries {
+			return nil, queryTimeoutErr(err)
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
 
-	response, err := r.queryResponse(request)
+	result, err := newQueryResult(response, r)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := newQueryResult(response, r)
+	return result, nil
+}
+func (r *Resource) nextRequest(recordURL string) (*http.Request, error) {
+	queryURL := r.session.InstanceURL() + recordURL
+	request, err := http.NewRequest(http.MethodGet, queryURL, nil)
+
 	if err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	request.Header.Add("Accept", "application/json")
+	sfdc.AcceptGzip(request)
+	r.session.AuthorizationHeader(request)
+	return request, nil
 }
 func (r *Resource) queryRequest(querier QueryFormatter, all bool) (*http.Request, error) {
+	if r.BatchSize != 0 && (r.BatchSize < MinBatchSize || r.BatchSize > MaxBatchSize) {
+		return nil, errors.Errorf("soql resource query: batch size %d must be between %d and %d", r.BatchSize, MinBatchSize, MaxBatchSize)
+	}
+
 	query, err := querier.Format()
 	if err != nil {
 		return nil, err
@@ -99,6 +173,9 @@ func (r *Resource) queryRequest(querier QueryFormatter, all bool) (*http.Request
 	form := url.Values{}
 	form.Add("q", query)
 	queryURL += "?" + form.Encode()
+	if len(queryURL) > sfdc.MaxURLLength {
+		return nil, errors.Wrapf(ErrQueryTooLong, "%d characters exceeds %d", len(queryURL), sfdc.MaxURLLength)
+	}
 
 	request, err := http.NewRequest(http.MethodGet, queryURL, nil)
 
@@ -107,6 +184,10 @@ func (r *Resource) queryRequest(querier QueryFormatter, all bool) (*http.Request
 	}
 
 	request.Header.Add("Accept", "application/json")
+	if r.BatchSize != 0 {
+		request.Header.Add("Sforce-Query-Options", "batchSize="+strconv.Itoa(r.BatchSize))
+	}
+	sfdc.AcceptGzip(request)
 	r.session.AuthorizationHeader(request)
 	return request, nil
 
@@ -117,9 +198,13 @@ func (r *Resource) queryResponse(request *http.Request) (queryResponse, error) {
 	if err != nil {
 		return queryResponse{}, err
 	}
+	defer response.Body.Close()
 
+	err = sfdc.DecompressResponse(response)
+	if err != nil {
+		return queryResponse{}, err
+	}
 	decoder := json.NewDecoder(response.Body)
-	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
 		return queryResponse{}, sfdc.HandleError(response)
@@ -133,3 +218,12 @@ func (r *Resource) queryResponse(request *http.Request) (queryResponse, error) {
 
 	return resp, nil
 }
+
+// queryTimeoutErr adds a suggestion to a QUERY_TIMEOUT error, which can still be
+// detected with sfdc.IsQueryTimeout.
+func queryTimeoutErr(err error) error {
+	if sfdc.IsQueryTimeout(err) {
+		return errors.Wrap(err, "soql resource query: query timed out, make the query more selective or run it with a bulk query job")
+	}
+	return err
+}
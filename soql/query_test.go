@@ -1,13 +1,19 @@
 package soql
 
 import (
+	"bytes"
+	"compress/gzip"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/enrique-esquivel/go-sfdc"
 	"github.com/enrique-esquivel/go-sfdc/session"
+	"github.com/pkg/errors"
 )
 
 type mockQuerier struct {
@@ -19,6 +25,14 @@ func (mock *mockQuerier) Format() (string, error) {
 	return mock.stmt, mock.err
 }
 
+func testGzip(body string) io.Reader {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	writer.Write([]byte(body))
+	writer.Close()
+	return &buf
+}
+
 func TestNewResource(t *testing.T) {
 	type args struct {
 		session session.ServiceFormatter
@@ -92,6 +106,29 @@ func TestResource_Query(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Query Too Long",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						return &http.Response{
+							StatusCode: 500,
+							Status:     "Unexpected Request",
+							Body:       ioutil.NopCloser(strings.NewReader("Error")),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			},
+			args: args{
+				querier: &mockQuerier{
+					stmt: "SELECT Id FROM Account WHERE Id IN ('" + strings.Repeat("001D000000K0fXOIAZ','", 1000) + "')",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "Response HTTP Error",
 			fields: fields{
@@ -229,6 +266,46 @@ func TestResource_Query(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Response Gzip",
+			fields: fields{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.Header.Get("Accept-Encoding") != "gzip" {
+							return &http.Response{
+								StatusCode: 500,
+								Status:     "Some Status",
+								Body:       ioutil.NopCloser(strings.NewReader("Error")),
+								Header:     make(http.Header),
+							}
+						}
+						resp := `{"done":true,"totalSize":0,"records":[]}`
+
+						return &http.Response{
+							StatusCode: 200,
+							Body:       ioutil.NopCloser(testGzip(resp)),
+							Header: http.Header{
+								"Content-Encoding": []string{"gzip"},
+							},
+						}
+					}),
+				},
+			},
+			args: args{
+				querier: &mockQuerier{
+					stmt: "SELECT Name FROM Account",
+				},
+			},
+			want: &QueryResult{
+				response: queryResponse{
+					Done:    true,
+					Records: []map[string]interface{}{},
+				},
+				records: []*QueryRecord{},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -374,3 +451,145 @@ func TestResource_next(t *testing.T) {
 		})
 	}
 }
+
+func TestResource_queryRequest_TooLong(t *testing.T) {
+	r := &Resource{
+		session: &mockSessionFormatter{
+			url: "https://test.salesforce.com",
+		},
+	}
+	querier := &mockQuerier{
+		stmt: "SELECT Id FROM Account WHERE Name = '" + strings.Repeat("a", sfdc.MaxURLLength) + "'",
+	}
+	_, err := r.queryRequest(querier, false)
+	if errors.Cause(err) != ErrQueryTooLong {
+		t.Errorf("Resource.queryRequest() error = %v, want %v", err, ErrQueryTooLong)
+	}
+}
+
+func TestResource_queryRequest_BatchSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		batchSize int
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "Default",
+			batchSize: 0,
+			want:      "",
+			wantErr:   false,
+		},
+		{
+			name:      "Passing",
+			batchSize: 500,
+			want:      "batchSize=500",
+			wantErr:   false,
+		},
+		{
+			name:      "Too Small",
+			batchSize: 199,
+			wantErr:   true,
+		},
+		{
+			name:      "Too Large",
+			batchSize: 2001,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resource{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+				},
+				BatchSize: tt.batchSize,
+			}
+			got, err := r.queryRequest(&mockQuerier{stmt: "SELECT Id FROM Account"}, false)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.queryRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == false && got.Header.Get("Sforce-Query-Options") != tt.want {
+				t.Errorf("Resource.queryRequest() Sforce-Query-Options = %v, want %v", got.Header.Get("Sforce-Query-Options"), tt.want)
+			}
+		})
+	}
+}
+
+func TestResource_next_QueryTimeout(t *testing.T) {
+	const timeout = `[{"message":"Your query request was running for too long.","errorCode":"QUERY_TIMEOUT"}]`
+	tests := []struct {
+		name      string
+		retries   int
+		timeouts  int
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "retried",
+			timeouts:  DefaultQueryTimeoutRetries,
+			wantCalls: DefaultQueryTimeoutRetries + 1,
+			wantErr:   false,
+		},
+		{
+			name:      "timed out",
+			timeouts:  DefaultQueryTimeoutRetries + 1,
+			wantCalls: DefaultQueryTimeoutRetries + 1,
+			wantErr:   true,
+		},
+		{
+			name:      "more retries",
+			retries:   4,
+			timeouts:  4,
+			wantCalls: 5,
+			wantErr:   false,
+		},
+		{
+			name:      "not retried",
+			retries:   -1,
+			timeouts:  1,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			r := &Resource{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						calls++
+						if calls <= tt.timeouts {
+							return &http.Response{
+								StatusCode: http.StatusBadRequest,
+								Status:     "400 Bad Request",
+								Body:       ioutil.NopCloser(strings.NewReader(timeout)),
+								Header:     make(http.Header),
+							}
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       ioutil.NopCloser(strings.NewReader(`{"done":true,"totalSize":0,"records":[]}`)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+				QueryTimeoutRetries: tt.retries,
+				QueryTimeoutWait:    time.Millisecond,
+			}
+			_, err := r.next("/services/data/v20.0/query/01gD0000002HU6KIAW-2000")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.next() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && sfdc.IsQueryTimeout(err) == false {
+				t.Errorf("Resource.next() error = %v, want a query timeout", err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("Resource.next() calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
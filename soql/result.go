@@ -1,6 +1,8 @@
 package soql
 
-import "errors"
+import (
+	"errors"
+)
 
 // QueryResult is returned from the SOQL query.  This will
 // allow for retrieving all of the records and query the
@@ -34,7 +36,9 @@ func (result *QueryResult) Done() bool {
 }
 
 // TotalSize is the total size of the query result.  This may
-// or may not be the size of the records in the result.
+// or may not be the size of the records in the result, since the
+// records can be split across pages that are retrieved with Next.  For
+// a query with a LIMIT, the total size is capped by the limit.
 func (result *QueryResult) TotalSize() int {
 	return result.response.TotalSize
 }
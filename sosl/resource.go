@@ -0,0 +1,108 @@
+// Package sosl provides the Salesforce SOSL search and the recently viewed
+// records, which are used for global search features.
+package sosl
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/enrique-esquivel/go-sfdc/session"
+	"github.com/pkg/errors"
+)
+
+// Resource is the structure for the Salesforce
+// SOSL API resource.
+type Resource struct {
+	session session.ServiceFormatter
+}
+
+// SearchResult is the result of the search.  The records of the objects that
+// matched are in the order of their relevance.
+type SearchResult struct {
+	SearchRecords []*sfdc.Record `json:"searchRecords"`
+}
+
+// NewResource forms the Salesforce SOSL resource. The
+// session formatter is required to form the proper URLs and authorization
+// header.
+func NewResource(session session.ServiceFormatter) (*Resource, error) {
+	if session == nil {
+		return nil, errors.New("sosl: session can not be nil")
+	}
+
+	err := session.Refresh()
+	if err != nil {
+		return nil, errors.Wrap(err, "session refresh")
+	}
+
+	return &Resource{
+		session: session,
+	}, nil
+}
+
+// Search will call out to the Salesforce org for a SOSL search, like
+// FIND {Acme} IN NAME FIELDS RETURNING Account(Id, Name).  The reserved
+// characters of the search term need to be escaped.
+func (r *Resource) Search(sosl string) (*SearchResult, error) {
+	if sosl == "" {
+		return nil, errors.New("sosl resource search: search can not be empty")
+	}
+
+	form := url.Values{}
+	form.Add("q", sosl)
+	searchURL := r.session.ServiceURL() + "/search/?" + form.Encode()
+	if len(searchURL) > sfdc.MaxURLLength {
+		return nil, errors.Errorf("sosl resource search: %d characters exceeds %d", len(searchURL), sfdc.MaxURLLength)
+	}
+
+	var result SearchResult
+	if err := r.get(searchURL, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RecentItems will call out to the Salesforce org for the records that the
+// user most recently viewed or referenced.  The limit is the most records that
+// are returned, and if zero, Salesforce returns up to 200 records.
+func (r *Resource) RecentItems(limit int) ([]*sfdc.Record, error) {
+	if limit < 0 {
+		return nil, errors.New("sosl resource recent items: limit can not be less than zero")
+	}
+
+	recentURL := r.session.ServiceURL() + "/recent/"
+	if limit > 0 {
+		recentURL += "?limit=" + strconv.Itoa(limit)
+	}
+
+	var records []*sfdc.Record
+	if err := r.get(recentURL, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (r *Resource) get(resourceURL string, value interface{}) error {
+	request, err := http.NewRequest(http.MethodGet, resourceURL, nil)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Add("Accept", "application/json")
+	r.session.AuthorizationHeader(request)
+
+	response, err := r.session.Client().Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return sfdc.HandleError(response)
+	}
+
+	return json.NewDecoder(response.Body).Decode(value)
+}
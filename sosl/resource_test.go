@@ -0,0 +1,224 @@
+package sosl
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/enrique-esquivel/go-sfdc/session"
+)
+
+func TestNewResource(t *testing.T) {
+	type args struct {
+		session session.ServiceFormatter
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *Resource
+		wantErr bool
+	}{
+		{
+			name: "New Resource",
+			args: args{
+				session: &mockSessionFormatter{
+					url: "Something",
+				},
+			},
+			want: &Resource{
+				session: &mockSessionFormatter{
+					url: "Something",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "No Session",
+			args:    args{},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Refresh Error",
+			args: args{
+				session: &mockSessionFormatter{
+					url:        "Something",
+					refreshErr: errors.New("refresh failed"),
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewResource(tt.args.session)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewResource() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewResource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResource_Search(t *testing.T) {
+	tests := []struct {
+		name    string
+		sosl    string
+		status  int
+		body    string
+		wantIDs []interface{}
+		wantErr bool
+	}{
+		{
+			name:   "Passing",
+			sosl:   "FIND {Acme} IN NAME FIELDS RETURNING Account(Id), Contact(Id)",
+			status: http.StatusOK,
+			body: `{"searchRecords":[` +
+				`{"attributes":{"type":"Account","url":"/services/data/v42.0/sobjects/Account/001D000000IqhSLIAZ"},"Id":"001D000000IqhSLIAZ"},` +
+				`{"attributes":{"type":"Contact","url":"/services/data/v42.0/sobjects/Contact/003D000000IqhSLIAZ"},"Id":"003D000000IqhSLIAZ"}]}`,
+			wantIDs: []interface{}{"001D000000IqhSLIAZ", "003D000000IqhSLIAZ"},
+			wantErr: false,
+		},
+		{
+			name:    "No Matches",
+			sosl:    "FIND {Nothing}",
+			status:  http.StatusOK,
+			body:    `{"searchRecords":[]}`,
+			wantErr: false,
+		},
+		{
+			name:    "Empty Search",
+			sosl:    "",
+			wantErr: true,
+		},
+		{
+			name:    "Too Long",
+			sosl:    "FIND {" + strings.Repeat("a", sfdc.MaxURLLength) + "}",
+			wantErr: true,
+		},
+		{
+			name:    "Salesforce Error",
+			sosl:    "FIND {Acme",
+			status:  http.StatusBadRequest,
+			body:    `[{"message":"line 1:6 mismatched character '<EOF>' expecting '}'","errorCode":"MALFORMED_SEARCH"}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resource{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com/services/data/v42.0",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.Path != "/services/data/v42.0/search/" || req.URL.Query().Get("q") != tt.sosl {
+							return &http.Response{
+								StatusCode: http.StatusInternalServerError,
+								Status:     "Invalid URL",
+								Body:       ioutil.NopCloser(strings.NewReader(req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+						return &http.Response{
+							StatusCode: tt.status,
+							Status:     http.StatusText(tt.status),
+							Body:       ioutil.NopCloser(strings.NewReader(tt.body)),
+							Header:     make(http.Header),
+						}
+					}),
+				},
+			}
+			got, err := r.Search(tt.sosl)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.Search() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			var ids []interface{}
+			for _, record := range got.SearchRecords {
+				id, _ := record.FieldValue("Id")
+				ids = append(ids, id)
+			}
+			if reflect.DeepEqual(ids, tt.wantIDs) == false {
+				t.Errorf("Resource.Search() = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestResource_RecentItems(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		wantQuery string
+		want      int
+		wantErr   bool
+	}{
+		{
+			name:      "Default Limit",
+			limit:     0,
+			wantQuery: "",
+			want:      2,
+			wantErr:   false,
+		},
+		{
+			name:      "Limit",
+			limit:     5,
+			wantQuery: "limit=5",
+			want:      2,
+			wantErr:   false,
+		},
+		{
+			name:    "Negative Limit",
+			limit:   -1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resource{
+				session: &mockSessionFormatter{
+					url: "https://test.salesforce.com/services/data/v42.0",
+					client: mockHTTPClient(func(req *http.Request) *http.Response {
+						if req.URL.Path != "/services/data/v42.0/recent/" || req.URL.RawQuery != tt.wantQuery {
+							return &http.Response{
+								StatusCode: http.StatusInternalServerError,
+								Status:     "Invalid URL",
+								Body:       ioutil.NopCloser(strings.NewReader(req.URL.String())),
+								Header:     make(http.Header),
+							}
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Status:     "OK",
+							Body: ioutil.NopCloser(strings.NewReader(`[` +
+								`{"attributes":{"type":"Account","url":"/services/data/v42.0/sobjects/Account/001D000000IqhSLIAZ"},"Id":"001D000000IqhSLIAZ","Name":"Acme"},` +
+								`{"attributes":{"type":"Contact","url":"/services/data/v42.0/sobjects/Contact/003D000000IqhSLIAZ"},"Id":"003D000000IqhSLIAZ","Name":"Gopher"}]`)),
+							Header: make(http.Header),
+						}
+					}),
+				},
+			}
+			got, err := r.RecentItems(tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resource.RecentItems() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("Resource.RecentItems() = %v, want %d records", got, tt.want)
+			}
+			if tt.want > 0 && got[0].SObject() != "Account" {
+				t.Errorf("Resource.RecentItems() SObject = %v, want Account", got[0].SObject())
+			}
+		})
+	}
+}
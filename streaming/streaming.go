@@ -0,0 +1,88 @@
+package streaming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/enrique-esquivel/go-sfdc/session"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ReplayNew will only deliver the events that are published after the subscription.
+	ReplayNew int64 = -1
+	// ReplayAll will deliver all of the events that are retained by Salesforce, followed
+	// by the new events.
+	ReplayAll int64 = -2
+)
+
+// Event is an event that was delivered to the subscription's channel.  The data is
+// the event's JSON, which can be unmarshaled to the event's struct.
+type Event struct {
+	Channel  string
+	ReplayID int64
+	Data     json.RawMessage
+}
+
+// Resource is the structure that can be used to subscribe to Platform Events,
+// Change Data Capture events and PushTopics.
+type Resource struct {
+	session session.ServiceFormatter
+}
+
+// NewResource creates a new streaming resource with the session.  If the session
+// is nil an error will be returned.
+func NewResource(session session.ServiceFormatter) (*Resource, error) {
+	if session == nil {
+		return nil, errors.New("streaming: session can not be nil")
+	}
+
+	err := session.Refresh()
+	if err != nil {
+		return nil, errors.Wrap(err, "session refresh")
+	}
+
+	return &Resource{
+		session: session,
+	}, nil
+}
+
+// Subscribe will subscribe to the channel, like /event/Order_Event__e or
+// /topic/AccountUpdates, over CometD long polling.  The replay ID is the ID of
+// the last event that was processed, or ReplayNew or ReplayAll.  The events are
+// delivered on the subscription's channel until the context is done, the
+// subscription is closed or the subscription fails.
+//
+// The long polling requests are held by Salesforce for up to two minutes, so the
+// session's HTTP client timeout needs to be longer than that.
+func (r *Resource) Subscribe(ctx context.Context, channel string, replayID int64) (*Subscription, error) {
+	if strings.HasPrefix(channel, "/") == false {
+		return nil, errors.New("streaming: channel must start with /")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	subscription := &Subscription{
+		session:  r.session,
+		url:      fmt.Sprintf("%s/cometd/%d.0", r.session.InstanceURL(), r.session.Version()),
+		channel:  channel,
+		replayID: replayID,
+		events:   make(chan Event),
+		done:     make(chan struct{}),
+		cancel:   cancel,
+	}
+
+	if err := subscription.handshake(ctx); err != nil {
+		cancel()
+		return nil, err
+	}
+	if err := subscription.subscribe(ctx); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go subscription.listen(ctx)
+
+	return subscription, nil
+}
@@ -0,0 +1,178 @@
+package streaming
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mockCometD(connects ...string) *http.Client {
+	connect := 0
+	return mockHTTPClient(func(req *http.Request) *http.Response {
+		response := func(code int, body string) *http.Response {
+			return &http.Response{
+				StatusCode: code,
+				Body:       ioutil.NopCloser(strings.NewReader(body)),
+				Header:     http.Header{"Set-Cookie": []string{"BAYEUX_BROWSER=abc"}},
+			}
+		}
+		if req.URL.String() != "https://test.salesforce.com/cometd/42.0" {
+			return response(http.StatusNotFound, `[{"message":"invalid url","errorCode":"NOT_FOUND"}]`)
+		}
+		var messages []message
+		if err := json.NewDecoder(req.Body).Decode(&messages); err != nil || len(messages) != 1 {
+			return response(http.StatusBadRequest, `[{"message":"invalid body","errorCode":"BAD_REQUEST"}]`)
+		}
+		msg := messages[0]
+		if msg.Channel != metaHandshake {
+			if cookie, err := req.Cookie("BAYEUX_BROWSER"); err != nil || cookie.Value != "abc" {
+				return response(http.StatusBadRequest, `[{"message":"missing cookie","errorCode":"BAD_REQUEST"}]`)
+			}
+		}
+		switch msg.Channel {
+		case metaHandshake:
+			return response(http.StatusOK, `[{"channel":"/meta/handshake","clientId":"client1","successful":true}]`)
+		case metaSubscribe:
+			if msg.Subscription != "/event/Order__e" {
+				return response(http.StatusOK, `[{"channel":"/meta/subscribe","successful":false,"error":"403:denied"}]`)
+			}
+			return response(http.StatusOK, `[{"channel":"/meta/subscribe","successful":true}]`)
+		case metaConnect:
+			if connect < len(connects) {
+				connect++
+				return response(http.StatusOK, connects[connect-1])
+			}
+			<-req.Context().Done()
+			return response(http.StatusOK, `[]`)
+		default:
+			return response(http.StatusOK, `[{"channel":"/meta/disconnect","successful":true}]`)
+		}
+	})
+}
+
+func TestResource_Subscribe(t *testing.T) {
+	type args struct {
+		channel  string
+		replayID int64
+	}
+	tests := []struct {
+		name         string
+		client       *http.Client
+		args         args
+		want         []Event
+		wantReplayID int64
+		wantSubErr   bool
+		wantErr      bool
+	}{
+		{
+			name: "events",
+			client: mockCometD(
+				`[{"channel":"/event/Order__e","data":{"event":{"replayId":10},"payload":{"Number__c":"1"}}},`+
+					`{"channel":"/meta/connect","successful":true}]`,
+				`[{"channel":"/event/Order__e","data":{"event":{"replayId":11},"payload":{"Number__c":"2"}}}]`,
+			),
+			args: args{
+				channel:  "/event/Order__e",
+				replayID: ReplayNew,
+			},
+			want: []Event{
+				{
+					Channel:  "/event/Order__e",
+					ReplayID: 10,
+					Data:     json.RawMessage(`{"event":{"replayId":10},"payload":{"Number__c":"1"}}`),
+				},
+				{
+					Channel:  "/event/Order__e",
+					ReplayID: 11,
+					Data:     json.RawMessage(`{"event":{"replayId":11},"payload":{"Number__c":"2"}}`),
+				},
+			},
+			wantReplayID: 11,
+			wantErr:      false,
+		},
+		{
+			name: "reconnect none",
+			client: mockCometD(
+				`[{"channel":"/meta/connect","successful":false,"error":"403::Unknown client","advice":{"reconnect":"none"}}]`,
+			),
+			args: args{
+				channel:  "/event/Order__e",
+				replayID: ReplayAll,
+			},
+			want:         nil,
+			wantReplayID: ReplayAll,
+			wantErr:      true,
+		},
+		{
+			name:   "subscribe denied",
+			client: mockCometD(),
+			args: args{
+				channel:  "/event/Other__e",
+				replayID: ReplayNew,
+			},
+			wantSubErr: true,
+		},
+		{
+			name:   "invalid channel",
+			client: mockCometD(),
+			args: args{
+				channel:  "event/Order__e",
+				replayID: ReplayNew,
+			},
+			wantSubErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resource{
+				session: &mockSessionFormatter{
+					url:    "https://test.salesforce.com",
+					client: tt.client,
+				},
+			}
+			subscription, err := r.Subscribe(context.Background(), tt.args.channel, tt.args.replayID)
+			if (err != nil) != tt.wantSubErr {
+				t.Errorf("Resource.Subscribe() error = %v, wantErr %v", err, tt.wantSubErr)
+				return
+			}
+			if tt.wantSubErr {
+				return
+			}
+
+			var got []Event
+			timeout := time.After(time.Second)
+		events:
+			for len(got) < len(tt.want) || tt.wantErr {
+				select {
+				case event, ok := <-subscription.Events():
+					if ok == false {
+						break events
+					}
+					got = append(got, event)
+				case <-timeout:
+					t.Errorf("Subscription.Events() timed out")
+					break events
+				}
+			}
+			if tt.wantErr == false {
+				if err := subscription.Close(); err != nil {
+					t.Errorf("Subscription.Close() error = %v", err)
+				}
+			}
+			if err := subscription.Err(); (err != nil) != tt.wantErr {
+				t.Errorf("Subscription.Err() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Subscription.Events() = %v, want %v", got, tt.want)
+			}
+			if got := subscription.ReplayID(); got != tt.wantReplayID {
+				t.Errorf("Subscription.ReplayID() = %v, want %v", got, tt.wantReplayID)
+			}
+		})
+	}
+}
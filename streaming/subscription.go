@@ -0,0 +1,274 @@
+package streaming
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/enrique-esquivel/go-sfdc"
+	"github.com/enrique-esquivel/go-sfdc/session"
+	"github.com/pkg/errors"
+)
+
+const (
+	metaHandshake      = "/meta/handshake"
+	metaSubscribe      = "/meta/subscribe"
+	metaConnect        = "/meta/connect"
+	metaDisconnect     = "/meta/disconnect"
+	longPolling        = "long-polling"
+	reconnectNone      = "none"
+	reconnectHandshake = "handshake"
+)
+
+var errUnauthorized = errors.New("streaming: session is not authorized")
+
+type message struct {
+	Channel                  string                 `json:"channel"`
+	ClientID                 string                 `json:"clientId,omitempty"`
+	Version                  string                 `json:"version,omitempty"`
+	SupportedConnectionTypes []string               `json:"supportedConnectionTypes,omitempty"`
+	ConnectionType           string                 `json:"connectionType,omitempty"`
+	Subscription             string                 `json:"subscription,omitempty"`
+	Successful               bool                   `json:"successful,omitempty"`
+	Error                    string                 `json:"error,omitempty"`
+	Advice                   *advice                `json:"advice,omitempty"`
+	Ext                      map[string]interface{} `json:"ext,omitempty"`
+	Data                     json.RawMessage        `json:"data,omitempty"`
+}
+
+type advice struct {
+	Reconnect string `json:"reconnect"`
+	Interval  int    `json:"interval"`
+}
+
+// Subscription is a subscription to a streaming channel.
+type Subscription struct {
+	session  session.ServiceFormatter
+	url      string
+	channel  string
+	clientID string
+	replayID int64
+	cookies  map[string]*http.Cookie
+	events   chan Event
+	err      error
+	done     chan struct{}
+	cancel   context.CancelFunc
+}
+
+// Events returns the channel that the events are delivered on.  The channel is
+// closed when the subscription ends.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Err returns the error that ended the subscription, once the events channel is
+// closed.  It is nil if the subscription was closed or its context was done.
+func (s *Subscription) Err() error {
+	<-s.done
+	return s.err
+}
+
+// ReplayID returns the replay ID of the last event that was delivered, which can
+// be used to resume the subscription.
+func (s *Subscription) ReplayID() int64 {
+	<-s.done
+	return s.replayID
+}
+
+// Close will stop the subscription and disconnect from Salesforce.
+func (s *Subscription) Close() error {
+	s.cancel()
+	<-s.done
+
+	_, err := s.send(context.Background(), message{
+		Channel:  metaDisconnect,
+		ClientID: s.clientID,
+	})
+	return err
+}
+
+func (s *Subscription) listen(ctx context.Context) {
+	defer close(s.done)
+	defer close(s.events)
+
+	for ctx.Err() == nil {
+		messages, err := s.send(ctx, message{
+			Channel:        metaConnect,
+			ClientID:       s.clientID,
+			ConnectionType: longPolling,
+		})
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err == errUnauthorized:
+			if err := s.session.Refresh(); err != nil {
+				s.err = errors.Wrap(err, "session refresh")
+				return
+			}
+			if err := s.resubscribe(ctx); err != nil {
+				s.err = err
+				return
+			}
+			continue
+		case err != nil:
+			s.err = err
+			return
+		}
+
+		for _, msg := range messages {
+			switch msg.Channel {
+			case metaConnect:
+				if err := s.reconnect(ctx, msg); err != nil {
+					s.err = err
+					return
+				}
+			case s.channel:
+				event := Event{
+					Channel: msg.Channel,
+					Data:    msg.Data,
+				}
+				var data struct {
+					Event struct {
+						ReplayID int64 `json:"replayId"`
+					} `json:"event"`
+				}
+				if err := json.Unmarshal(msg.Data, &data); err == nil {
+					event.ReplayID = data.Event.ReplayID
+				}
+				select {
+				case s.events <- event:
+					s.replayID = event.ReplayID
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *Subscription) reconnect(ctx context.Context, msg message) error {
+	if msg.Successful || ctx.Err() != nil {
+		return nil
+	}
+	if msg.Advice == nil {
+		return errors.New("streaming connect: " + msg.Error)
+	}
+	if msg.Advice.Interval > 0 {
+		timer := time.NewTimer(time.Duration(msg.Advice.Interval) * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+	switch msg.Advice.Reconnect {
+	case reconnectNone:
+		return errors.New("streaming connect: " + msg.Error)
+	case reconnectHandshake:
+		return s.resubscribe(ctx)
+	default:
+		return nil
+	}
+}
+
+func (s *Subscription) resubscribe(ctx context.Context) error {
+	if err := s.handshake(ctx); err != nil {
+		return err
+	}
+	return s.subscribe(ctx)
+}
+
+func (s *Subscription) handshake(ctx context.Context) error {
+	messages, err := s.send(ctx, message{
+		Channel:                  metaHandshake,
+		Version:                  "1.0",
+		SupportedConnectionTypes: []string{longPolling},
+		Ext: map[string]interface{}{
+			"replay": true,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "streaming handshake")
+	}
+	if len(messages) == 0 || messages[0].Successful == false {
+		return errors.New("streaming handshake: " + errorMessage(messages))
+	}
+	s.clientID = messages[0].ClientID
+	return nil
+}
+
+func (s *Subscription) subscribe(ctx context.Context) error {
+	messages, err := s.send(ctx, message{
+		Channel:      metaSubscribe,
+		ClientID:     s.clientID,
+		Subscription: s.channel,
+		Ext: map[string]interface{}{
+			"replay": map[string]int64{
+				s.channel: s.replayID,
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "streaming subscribe")
+	}
+	if len(messages) == 0 || messages[0].Successful == false {
+		return errors.New("streaming subscribe: " + errorMessage(messages))
+	}
+	return nil
+}
+
+func (s *Subscription) send(ctx context.Context, messages ...message) ([]message, error) {
+	body, err := json.Marshal(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Accept", "application/json")
+	request.Header.Add("Content-Type", "application/json")
+	for _, cookie := range s.cookies {
+		request.AddCookie(cookie)
+	}
+	s.session.AuthorizationHeader(request)
+
+	response, err := s.session.Client().Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	for _, cookie := range response.Cookies() {
+		if s.cookies == nil {
+			s.cookies = make(map[string]*http.Cookie)
+		}
+		s.cookies[cookie.Name] = cookie
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, errUnauthorized
+	default:
+		return nil, sfdc.HandleError(response)
+	}
+
+	var values []message
+	err = json.NewDecoder(response.Body).Decode(&values)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func errorMessage(messages []message) string {
+	if len(messages) == 0 {
+		return "no response"
+	}
+	return messages[0].Error
+}
@@ -0,0 +1,59 @@
+package sfdc
+
+import (
+	"context"
+	"net/http"
+)
+
+// Tracer is notified of every request made with a session, which allows the
+// Salesforce callouts to be traced, like with OpenTelemetry spans, without
+// the library depending on a tracing implementation.
+type Tracer interface {
+	// StartRequest is called before the request is sent with the attributes the
+	// resources added to the request, like the object, operation and job ID.
+	StartRequest(request *http.Request, attributes map[string]string) Span
+}
+
+// Span is a traced request.
+type Span interface {
+	// End is called with the response, or the error, of the request.
+	End(response *http.Response, err error)
+}
+
+// Trace attributes added by the resources to their requests.  The record counts
+// are added to the information and results requests of the bulk jobs, and are the
+// counts of the job information that was last retrieved, since the request is
+// traced before its response is known.
+const (
+	TraceObject           = "sfdc.object"
+	TraceOperation        = "sfdc.operation"
+	TraceJobID            = "sfdc.job.id"
+	TraceRecordsProcessed = "sfdc.records.processed"
+	TraceRecordsFailed    = "sfdc.records.failed"
+)
+
+type traceAttributesKey struct{}
+
+// WithTraceAttributes returns a copy of the request with the attributes added to
+// the ones the tracer will receive.  Empty values are ignored.
+func WithTraceAttributes(request *http.Request, attributes map[string]string) *http.Request {
+	merged := TraceAttributes(request)
+	for key, value := range attributes {
+		if value != "" {
+			merged[key] = value
+		}
+	}
+	ctx := context.WithValue(request.Context(), traceAttributesKey{}, merged)
+	return request.WithContext(ctx)
+}
+
+// TraceAttributes returns a copy of the trace attributes of the request.
+func TraceAttributes(request *http.Request) map[string]string {
+	attributes := make(map[string]string)
+	if existing, ok := request.Context().Value(traceAttributesKey{}).(map[string]string); ok {
+		for key, value := range existing {
+			attributes[key] = value
+		}
+	}
+	return attributes
+}
@@ -0,0 +1,38 @@
+package sfdc
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestWithTraceAttributes(t *testing.T) {
+	request, err := http.NewRequest(http.MethodGet, "https://test.salesforce.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := TraceAttributes(request); len(got) != 0 {
+		t.Errorf("TraceAttributes() = %v, want empty", got)
+	}
+
+	traced := WithTraceAttributes(request, map[string]string{
+		TraceObject:    "Account",
+		TraceOperation: "insert",
+	})
+	traced = WithTraceAttributes(traced, map[string]string{
+		TraceJobID:     "1234",
+		TraceOperation: "",
+	})
+
+	want := map[string]string{
+		TraceObject:    "Account",
+		TraceOperation: "insert",
+		TraceJobID:     "1234",
+	}
+	if got := TraceAttributes(traced); !reflect.DeepEqual(got, want) {
+		t.Errorf("TraceAttributes() = %v, want %v", got, want)
+	}
+	if got := TraceAttributes(request); len(got) != 0 {
+		t.Errorf("TraceAttributes() of the original request = %v, want empty", got)
+	}
+}
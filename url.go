@@ -0,0 +1,7 @@
+package sfdc
+
+// MaxURLLength is the longest request URL, including the encoded query string,
+// that Salesforce accepts.  The resources that send their statement in the URL,
+// like the soql query and the sosl search, return an error instead of sending a
+// longer request.
+const MaxURLLength = 16384